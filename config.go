@@ -18,9 +18,11 @@ type Config struct {
 	HTTPAddr             string
 	REPL                 bool
 	FrameSize            int
+	ControlPeriod        int
 	SampleRate           float64
 	SingleSampleDisabled bool
 	FadeIn               int
+	Smoothing            int
 	Gain                 float64
 
 	Backend string
@@ -31,7 +33,8 @@ type Config struct {
 	DeviceLatency   string
 	DeviceFrameSize int
 
-	ScriptPath string
+	ScriptPath    string
+	UnitReference bool
 }
 
 func parseArgs(args []string) (Config, error) {
@@ -41,11 +44,13 @@ func parseArgs(args []string) (Config, error) {
 
 	set.Int64Var(&cfg.Seed, "seed", 0, "random seed")
 	set.IntVar(&cfg.FrameSize, "frame", 256, "frame size used within the synthesis engine")
+	set.IntVar(&cfg.ControlPeriod, "control-period", 64, "number of samples between reads of slowly changing unit inputs")
 	set.StringVar(&cfg.HTTPAddr, "addr", ":5000", "http address to serve")
 	set.BoolVar(&cfg.REPL, "repl", false, "REPL")
 	set.Float64Var(&cfg.SampleRate, "samplerate", 44.1, "sample rate (8, 22.05, 44.1, 48.0)")
 	set.BoolVar(&cfg.SingleSampleDisabled, "disable-single-sample", false, "disables single-sample mode for feedback loops")
 	set.IntVar(&cfg.FadeIn, "fade-in", 100, "Duration of fade-in (milliseconds) once output signal is detected")
+	set.IntVar(&cfg.Smoothing, "smoothing", 0, "Duration (milliseconds) of the ramp used when patching constant values into inputs")
 	set.Float64Var(&cfg.Gain, "gain", 0, "gain decibels (dB)")
 
 	set.BoolVar(&cfg.DeviceList, "device-list", false, "list all devices")
@@ -54,6 +59,8 @@ func parseArgs(args []string) (Config, error) {
 	set.StringVar(&cfg.DeviceLatency, "device-latency", "low", "latency setting for audio device")
 	set.IntVar(&cfg.DeviceFrameSize, "device-frame", 1024, "frame size used when writing to audio device")
 
+	set.BoolVar(&cfg.UnitReference, "unit-reference", false, "print a Markdown reference of all units and exit")
+
 	set.StringVar(&cfg.Backend, "backend", "portaudio", "driver (portaudio, stdout)")
 
 	err := set.Parse(args)
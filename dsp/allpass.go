@@ -1,5 +1,7 @@
 package dsp
 
+import "math"
+
 // NewAllPassMS returns a new AllPass
 func NewAllPassMS(ms MS) *AllPass {
 	return &AllPass{dl: NewDelayLineMS(ms)}
@@ -32,3 +34,23 @@ func (a *AllPass) TickAbsolute(in, gain, delay float64) float64 {
 func (a *AllPass) TickRelative(in, gain, scale float64) float64 {
 	return a.TickAbsolute(in, gain, float64(len(a.dl.buffer))*scale)
 }
+
+// FirstOrderAllPass is a one-pole allpass filter. It passes every frequency at the same level and shifts their phase
+// by up to 180 degrees, reaching 90 degrees at a break frequency set by its coefficient.
+type FirstOrderAllPass struct {
+	lastIn, lastOut float64
+}
+
+// Tick advances the filter state with a coefficient in the range (-1, 1)
+func (a *FirstOrderAllPass) Tick(in, coef float64) float64 {
+	a.lastOut = coef*(in-a.lastOut) + a.lastIn
+	a.lastIn = in
+	return a.lastOut
+}
+
+// AllPassCoefficient returns the coefficient of a FirstOrderAllPass whose phase shift is 90 degrees at a frequency,
+// given as a fraction of the sample rate.
+func AllPassCoefficient(freq float64) float64 {
+	t := Tan(math.Pi * Clamp(freq, 1e-6, 0.49))
+	return (t - 1) / (t + 1)
+}
@@ -1,6 +1,7 @@
 package dsp
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -43,3 +44,19 @@ func TestAllPass_TickRelative(t *testing.T) {
 	}
 	require.Equal(t, []float64{0, 0.5, 1, 1.5, 2, 3.5, 4.75, 6, 7.25, 8.5}, out)
 }
+
+func TestFirstOrderAllPass(t *testing.T) {
+	// A sine at the break frequency comes out at the same level, a quarter of a cycle behind.
+	var (
+		freq = 0.05
+		ap   FirstOrderAllPass
+		coef = AllPassCoefficient(freq)
+		out  []float64
+	)
+	for i := 0; i < 1000; i++ {
+		out = append(out, ap.Tick(math.Sin(2*math.Pi*freq*float64(i)), coef))
+	}
+	for i := 900; i < 1000; i++ {
+		require.InDelta(t, -math.Cos(2*math.Pi*freq*float64(i)), out[i], 1e-3)
+	}
+}
@@ -0,0 +1,116 @@
+package dsp
+
+import "math"
+
+// BiquadTypes for Biquad
+const (
+	Peak BiquadType = iota
+	LowShelf
+	HighShelf
+	Notch
+	LowCut
+	HighCut
+	BandPass
+)
+
+// BiquadType describes the response of a Biquad.
+type BiquadType int
+
+// Biquad is a second-order filter whose coefficients follow Robert Bristow-Johnson's Audio EQ Cookbook. Cutoff is
+// the center or corner frequency, Gain is the linear gain of peaks and shelves and Q is the bandwidth of peaks, notches
+// and band-passes, the slope of shelves and the resonance of cuts. Band-passes have unit gain at their center.
+type Biquad struct {
+	Type            BiquadType
+	Cutoff, Gain, Q float64
+
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+
+	// settings the coefficients were last calculated for
+	lastType                    BiquadType
+	lastCutoff, lastGain, lastQ float64
+	calculated                  bool
+}
+
+// Tick advances the operation
+func (f *Biquad) Tick(in float64) float64 {
+	if !f.calculated || f.Type != f.lastType || f.Cutoff != f.lastCutoff || f.Gain != f.lastGain || f.Q != f.lastQ {
+		f.calculate()
+	}
+
+	// Transposed direct form II
+	out := f.b0*in + f.z1
+	f.z1 = f.b1*in - f.a1*out + f.z2
+	f.z2 = f.b2*in - f.a2*out
+	return out
+}
+
+func (f *Biquad) calculate() {
+	f.lastType, f.lastCutoff, f.lastGain, f.lastQ = f.Type, f.Cutoff, f.Gain, f.Q
+	f.calculated = true
+
+	var (
+		w0    = 2 * math.Pi * Clamp(math.Abs(f.Cutoff), 1e-6, 0.49)
+		cosw0 = math.Cos(w0)
+		alpha = math.Sin(w0) / (2 * math.Max(f.Q, 0.01))
+		a     = math.Sqrt(math.Max(f.Gain, 1e-6))
+		shelf = 2 * math.Sqrt(a) * alpha // 2√A·α
+
+		b0, b1, b2, a0, a1, a2 float64
+	)
+
+	switch f.Type {
+	case LowShelf:
+		b0 = a * ((a + 1) - (a-1)*cosw0 + shelf)
+		b1 = 2 * a * ((a - 1) - (a+1)*cosw0)
+		b2 = a * ((a + 1) - (a-1)*cosw0 - shelf)
+		a0 = (a + 1) + (a-1)*cosw0 + shelf
+		a1 = -2 * ((a - 1) + (a+1)*cosw0)
+		a2 = (a + 1) + (a-1)*cosw0 - shelf
+	case HighShelf:
+		b0 = a * ((a + 1) + (a-1)*cosw0 + shelf)
+		b1 = -2 * a * ((a - 1) + (a+1)*cosw0)
+		b2 = a * ((a + 1) + (a-1)*cosw0 - shelf)
+		a0 = (a + 1) - (a-1)*cosw0 + shelf
+		a1 = 2 * ((a - 1) - (a+1)*cosw0)
+		a2 = (a + 1) - (a-1)*cosw0 - shelf
+	case Notch:
+		b0 = 1
+		b1 = -2 * cosw0
+		b2 = 1
+		a0 = 1 + alpha
+		a1 = -2 * cosw0
+		a2 = 1 - alpha
+	case LowCut:
+		b0 = (1 + cosw0) / 2
+		b1 = -(1 + cosw0)
+		b2 = (1 + cosw0) / 2
+		a0 = 1 + alpha
+		a1 = -2 * cosw0
+		a2 = 1 - alpha
+	case BandPass:
+		b0 = alpha
+		b1 = 0
+		b2 = -alpha
+		a0 = 1 + alpha
+		a1 = -2 * cosw0
+		a2 = 1 - alpha
+	case HighCut:
+		b0 = (1 - cosw0) / 2
+		b1 = 1 - cosw0
+		b2 = (1 - cosw0) / 2
+		a0 = 1 + alpha
+		a1 = -2 * cosw0
+		a2 = 1 - alpha
+	default:
+		b0 = 1 + alpha*a
+		b1 = -2 * cosw0
+		b2 = 1 - alpha*a
+		a0 = 1 + alpha/a
+		a1 = -2 * cosw0
+		a2 = 1 - alpha/a
+	}
+
+	f.b0, f.b1, f.b2 = b0/a0, b1/a0, b2/a0
+	f.a1, f.a2 = a1/a0, a2/a0
+}
@@ -0,0 +1,56 @@
+package dsp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// biquadGain measures the gain of a filter at a frequency from its response to a sine.
+func biquadGain(f *Biquad, freq float64) float64 {
+	var peak float64
+	for i := 0; i < sampleRate; i++ {
+		out := f.Tick(math.Sin(2 * math.Pi * freq * float64(i)))
+		if i > sampleRate/2 {
+			peak = math.Max(peak, math.Abs(out))
+		}
+	}
+	return peak
+}
+
+func TestBiquad(t *testing.T) {
+	var (
+		cutoff = Frequency(1000, sampleRate).Float64()
+		low    = Frequency(20, sampleRate).Float64()
+		high   = Frequency(18000, sampleRate).Float64()
+		boost  = math.Pow(10, 6.0/20)
+	)
+
+	tests := []struct {
+		name     string
+		typ      BiquadType
+		freq     float64
+		expected float64
+	}{
+		{"peak at center", Peak, cutoff, boost},
+		{"peak away from center", Peak, low, 1},
+		{"low shelf below", LowShelf, low, boost},
+		{"low shelf above", LowShelf, high, 1},
+		{"high shelf below", HighShelf, low, 1},
+		{"high shelf above", HighShelf, high, boost},
+		{"notch at center", Notch, cutoff, 0},
+		{"notch away from center", Notch, low, 1},
+		{"low cut below", LowCut, low, 0},
+		{"low cut at cutoff", LowCut, cutoff, math.Sqrt(0.5)},
+		{"low cut above", LowCut, high, 1},
+		{"high cut below", HighCut, low, 1},
+		{"high cut at cutoff", HighCut, cutoff, math.Sqrt(0.5)},
+		{"high cut above", HighCut, high, 0},
+		{"band pass at center", BandPass, cutoff, 1},
+	}
+	for _, test := range tests {
+		f := &Biquad{Type: test.typ, Cutoff: cutoff, Gain: boost, Q: math.Sqrt(0.5)}
+		require.InDelta(t, test.expected, biquadGain(f, test.freq), 0.02, test.name)
+	}
+}
@@ -0,0 +1,84 @@
+package dsp
+
+// BLEP returns the polynomial band-limited step residual for a step of height 1, measured `s` samples after the
+// discontinuity. The residual spans one sample either side of the discontinuity (-1 < s < 1) and is added to the
+// naive signal.
+func BLEP(s float64) float64 {
+	switch {
+	case s <= -1 || s >= 1:
+		return 0
+	case s < 0:
+		return (1 + s) * (1 + s) / 2
+	default:
+		return -(1 - s) * (1 - s) / 2
+	}
+}
+
+// BLAMP returns the polynomial band-limited ramp residual for a change in slope of 1 per sample, measured `s` samples
+// after the discontinuity. It's the integral of BLEP and is used to round off corners (e.g. the peaks of a triangle
+// wave).
+func BLAMP(s float64) float64 {
+	switch {
+	case s <= -1 || s >= 1:
+		return 0
+	case s < 0:
+		return (1 + s) * (1 + s) * (1 + s) / 6
+	default:
+		return (1 - s) * (1 - s) * (1 - s) / 6
+	}
+}
+
+// PolyBLEP returns the band-limited step residual for a discontinuity at phase 0 of a waveform, where `t` is a
+// normalized phase [0, 1) that advances `dt` per sample. The residual describes a step of height -2 (e.g. a sawtooth
+// falling from 1 to -1), so it's subtracted from the naive waveform.
+func PolyBLEP(t, dt float64) float64 {
+	switch {
+	case dt <= 0:
+		return 0
+	case t < dt:
+		return 2 * BLEP(t/dt)
+	case t > 1-dt:
+		return 2 * BLEP((t-1)/dt)
+	}
+	return 0
+}
+
+// PolyBLAMP returns the band-limited ramp residual for a change in slope of 1 per sample at phase 0 of a waveform,
+// where `t` is a normalized phase [0, 1) that advances `dt` per sample.
+func PolyBLAMP(t, dt float64) float64 {
+	switch {
+	case dt <= 0:
+		return 0
+	case t < dt:
+		return BLAMP(t / dt)
+	case t > 1-dt:
+		return BLAMP((t - 1) / dt)
+	}
+	return 0
+}
+
+// BLEPBuffer applies band-limited corrections to both sides of discontinuities that happen between samples. It
+// delays the signal by one sample so the sample preceding a discontinuity can still be corrected once the
+// discontinuity is known about.
+type BLEPBuffer struct {
+	prev, cur float64
+}
+
+// Step registers a step of height `h` that happened `s` samples (0 <= s < 1) before the current sample.
+func (b *BLEPBuffer) Step(s, h float64) {
+	b.prev += h * BLEP(s-1)
+	b.cur += h * BLEP(s)
+}
+
+// Ramp registers a change in slope of `h` per sample that happened `s` samples (0 <= s < 1) before the current sample.
+func (b *BLEPBuffer) Ramp(s, h float64) {
+	b.prev += h * BLAMP(s-1)
+	b.cur += h * BLAMP(s)
+}
+
+// Tick accepts the naive value of the current sample and returns the corrected value of the previous one.
+func (b *BLEPBuffer) Tick(v float64) float64 {
+	out := b.prev
+	b.prev, b.cur = b.cur+v, 0
+	return out
+}
@@ -0,0 +1,45 @@
+package dsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLEP(t *testing.T) {
+	// The residual bridges the step: it's continuous at the discontinuity once the step itself is added.
+	require.InDelta(t, BLEP(-1e-9), BLEP(0)+1, 1e-6)
+	require.Equal(t, 0.0, BLEP(-1))
+	require.Equal(t, 0.0, BLEP(1))
+	require.Equal(t, 0.0, BLEP(2))
+}
+
+func TestBLAMP(t *testing.T) {
+	require.InDelta(t, BLAMP(-1e-9), BLAMP(0), 1e-6)
+	require.Equal(t, 0.0, BLAMP(-1))
+	require.Equal(t, 0.0, BLAMP(1))
+}
+
+func TestPolyBLEP(t *testing.T) {
+	dt := 0.1
+	require.Equal(t, 0.0, PolyBLEP(0.5, dt))
+	require.Equal(t, 0.0, PolyBLEP(0.5, 0))
+	require.InDelta(t, -1.0, PolyBLEP(0, dt), 1e-9)
+	require.InDelta(t, 1.0, PolyBLEP(1-1e-9, dt), 1e-6)
+}
+
+func TestBLEPBuffer(t *testing.T) {
+	var b BLEPBuffer
+
+	// Naive step from -1 to 1 halfway between the first and second samples.
+	require.Equal(t, 0.0, b.Tick(-1))
+	b.Step(0.5, 2)
+	first := b.Tick(1)
+	second := b.Tick(1)
+	third := b.Tick(1)
+
+	require.InDelta(t, -1+2*BLEP(-0.5), first, 1e-9)
+	require.InDelta(t, 1+2*BLEP(0.5), second, 1e-9)
+	require.Equal(t, 1.0, third)
+	require.True(t, first > -1 && first < second && second < 1)
+}
@@ -0,0 +1,157 @@
+package dsp
+
+// maxConvolutionBlock is the largest partition that a non-uniform Convolver grows to.
+const maxConvolutionBlock = 8192
+
+// Convolver convolves a signal with an impulse response without latency. The first block of the impulse response is
+// applied directly and the rest is split into partitions that are applied in the frequency domain by overlap-save.
+//
+// Uniform partitioning uses partitions of the first block's size throughout, which spreads the work evenly. Non-uniform
+// partitioning uses partitions that grow along the impulse response, which takes far less work overall for long
+// responses but does more of it at once every so often.
+type Convolver struct {
+	head    []float64 // first block of the impulse response, applied directly
+	history []float64 // recent input, held twice over in a ring
+	pos     int
+	levels  []*convolutionLevel
+}
+
+// NewConvolver returns a new Convolver for an impulse response. The block size must be a power of two.
+func NewConvolver(ir []float64, block int, uniform bool) *Convolver {
+	if !IsPowerOfTwo(block) {
+		panic("dsp: convolution block size must be a power of two")
+	}
+
+	headLen := block
+	if headLen > len(ir) {
+		headLen = len(ir)
+	}
+	c := &Convolver{
+		head:    append([]float64{}, ir[:headLen]...),
+		history: make([]float64, 2*block),
+	}
+
+	for start, size := block, block; start < len(ir); {
+		end := len(ir)
+		if !uniform && size < maxConvolutionBlock {
+			// Each size runs up to 16 of its partitions into the impulse response, where the next size, four times
+			// larger, takes over on a multiple of its own partitions.
+			end = 16 * size
+			if end > len(ir) {
+				end = len(ir)
+			}
+		}
+		c.levels = append(c.levels, newConvolutionLevel(ir[start:end], start, size))
+		start = end
+		if !uniform && size < maxConvolutionBlock {
+			size = min(4*size, maxConvolutionBlock)
+		}
+	}
+	return c
+}
+
+// Tick advances the operation
+func (c *Convolver) Tick(in float64) float64 {
+	// The input history is kept twice over so that the most recent len(head) samples are always contiguous.
+	n := len(c.head)
+	size := len(c.history) / 2
+	c.history[c.pos] = in
+	c.history[c.pos+size] = in
+
+	var out float64
+	if n > 0 {
+		recent := c.history[c.pos+size-n+1 : c.pos+size+1]
+		for k, h := range c.head {
+			out += h * recent[n-1-k]
+		}
+	}
+	c.pos = (c.pos + 1) % size
+
+	for _, l := range c.levels {
+		out += l.tick(in)
+	}
+	return out
+}
+
+// convolutionLevel applies a segment of an impulse response, split into partitions of equal size, by uniformly
+// partitioned overlap-save convolution. Its output is one block late, so the segment must start at least one block
+// into the impulse response; any further offset is made up by reaching further back into the input spectra.
+type convolutionLevel struct {
+	block      int
+	offset     int // blocks by which the spectra are delayed beyond the level's own latency
+	partitions [][]complex128
+	spectra    [][]complex128 // input spectra, most recent first
+	input      []float64      // the last two blocks of input
+	output     []float64
+	scratch    []complex128
+	pos        int
+}
+
+func newConvolutionLevel(segment []float64, start, block int) *convolutionLevel {
+	var (
+		count = (len(segment) + block - 1) / block
+		l     = &convolutionLevel{
+			block:      block,
+			offset:     start/block - 1,
+			partitions: make([][]complex128, count),
+			input:      make([]float64, 2*block),
+			output:     make([]float64, block),
+			scratch:    make([]complex128, 2*block),
+		}
+	)
+	for k := range l.partitions {
+		p := make([]complex128, 2*block)
+		for j := 0; j < block && k*block+j < len(segment); j++ {
+			p[j] = complex(segment[k*block+j], 0)
+		}
+		FFT(p)
+		l.partitions[k] = p
+	}
+	l.spectra = make([][]complex128, count+l.offset)
+	for k := range l.spectra {
+		l.spectra[k] = make([]complex128, 2*block)
+	}
+	return l
+}
+
+func (l *convolutionLevel) tick(in float64) float64 {
+	out := l.output[l.pos]
+	l.input[l.block+l.pos] = in
+	l.pos++
+	if l.pos == l.block {
+		l.pos = 0
+		l.process()
+	}
+	return out
+}
+
+// process transforms the block of input just completed and computes the next block of output.
+func (l *convolutionLevel) process() {
+	// Reuse the oldest spectrum for the newest.
+	last := len(l.spectra) - 1
+	x := l.spectra[last]
+	copy(l.spectra[1:], l.spectra[:last])
+	l.spectra[0] = x
+	for j, v := range l.input {
+		x[j] = complex(v, 0)
+	}
+	FFT(x)
+	copy(l.input, l.input[l.block:])
+
+	y := l.scratch
+	for j := range y {
+		y[j] = 0
+	}
+	for k, h := range l.partitions {
+		x := l.spectra[k+l.offset]
+		for j := range y {
+			y[j] += h[j] * x[j]
+		}
+	}
+	IFFT(y)
+
+	// The first half of the result is wrapped around by the circular convolution and is discarded.
+	for j := range l.output {
+		l.output[j] = real(y[l.block+j])
+	}
+}
@@ -0,0 +1,67 @@
+package dsp
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvolver(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func(n int) []float64 {
+		s := make([]float64, n)
+		for i := range s {
+			s[i] = r.Float64()*2 - 1
+		}
+		return s
+	}
+
+	for _, irLen := range []int{1, 50, 64, 1000, 12000} {
+		var (
+			ir       = random(irLen)
+			in       = random(20000)
+			expected = make([]float64, len(in))
+		)
+		for n := range expected {
+			for k := 0; k < len(ir) && k <= n; k++ {
+				expected[n] += ir[k] * in[n-k]
+			}
+		}
+
+		for _, uniform := range []bool{true, false} {
+			c := NewConvolver(ir, 64, uniform)
+			for n, v := range in {
+				require.InDelta(t, expected[n], c.Tick(v), 1e-8, "length %d, uniform %v, sample %d", irLen, uniform, n)
+			}
+		}
+	}
+}
+
+func TestConvolver_MaxBlock(t *testing.T) {
+	// A sparse impulse response long enough for the partitions to stop growing.
+	ir := make([]float64, 90000)
+	taps := []int{0, 100, 5000, 40000, 70000, 89999}
+	for i, k := range taps {
+		ir[k] = float64(i+1) / 10
+	}
+
+	c := NewConvolver(ir, 64, false)
+	for _, l := range c.levels {
+		require.True(t, l.block <= maxConvolutionBlock, "block %d", l.block)
+	}
+	require.Equal(t, maxConvolutionBlock, c.levels[len(c.levels)-1].block)
+
+	r := rand.New(rand.NewSource(1))
+	in := make([]float64, len(ir)+1000)
+	for n := range in {
+		in[n] = r.Float64()*2 - 1
+		var expected float64
+		for _, k := range taps {
+			if k <= n {
+				expected += ir[k] * in[n-k]
+			}
+		}
+		require.InDelta(t, expected, c.Tick(in[n]), 1e-8, "sample %d", n)
+	}
+}
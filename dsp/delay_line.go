@@ -66,3 +66,72 @@ func (d *DelayLine) ReadAbsolute(pos float64) float64 {
 func (d *DelayLine) ReadRelative(scale float64) float64 {
 	return d.ReadAbsolute(scale * float64(len(d.buffer)-1))
 }
+
+// Interpolations for reading between the samples of a DelayLine
+const (
+	InterpolateLinear Interpolation = iota
+	InterpolateCubic
+	InterpolateAllPass
+)
+
+// Interpolation describes how a DelayTap reads between samples.
+type Interpolation int
+
+// ReadCubic reads a specific sample offset from the DelayLine, interpolating with a cubic Hermite curve through the
+// samples either side of it
+func (d *DelayLine) ReadCubic(pos float64) float64 {
+	durationI, durationF := math.Modf(pos)
+	var (
+		size   = len(d.buffer)
+		whole  = int(durationI)
+		offset = d.offset + whole
+		at     = func(i int) float64 { return d.buffer[((offset+i)%size+size)%size] }
+		x0     = at(-1)
+	)
+	// After a write, position 1 holds the newest sample; there's nothing more recent to curve toward.
+	if whole <= 1 {
+		x0 = at(0)
+	}
+	return Hermite(x0, at(0), at(1), at(2), durationF)
+}
+
+// DelayTap reads from a DelayLine at a delay that can change from sample to sample. It should be read once for every
+// sample written to the DelayLine.
+type DelayTap struct {
+	Interpolation Interpolation
+	// Slew is the time constant, in samples, with which the tap follows changes to its delay. At zero it follows them
+	// immediately; otherwise it glides toward them like a tape head, bending the pitch of the signal as it goes.
+	Slew float64
+
+	delay, coef, lastSlew float64
+	started               bool
+	ap                    FirstOrderAllPass
+}
+
+// Read reads from the DelayLine at a position, measured as with ReadAbsolute, moving toward it as set by Slew
+func (t *DelayTap) Read(d *DelayLine, pos float64) float64 {
+	if !t.started || t.Slew <= 0 {
+		t.delay = pos
+		t.started = true
+	} else {
+		if t.Slew != t.lastSlew {
+			t.coef = 1 - math.Exp(-1/t.Slew)
+			t.lastSlew = t.Slew
+		}
+		t.delay += (pos - t.delay) * t.coef
+	}
+
+	switch t.Interpolation {
+	case InterpolateCubic:
+		return d.ReadCubic(t.delay)
+	case InterpolateAllPass:
+		// The whole samples are read from the DelayLine and the fraction is made up by an allpass filter, kept to
+		// between 0.5 and 1.5 samples where its delay is flattest. Unlike the other interpolations, it doesn't dull
+		// the signal, but it rings briefly when the delay jumps.
+		whole := math.Max(math.Floor(t.delay-0.5), 1)
+		frac := math.Max(t.delay-whole, 0.5)
+		return t.ap.Tick(d.ReadAbsolute(whole), (1-frac)/(1+frac))
+	default:
+		return d.ReadAbsolute(t.delay)
+	}
+}
@@ -1,6 +1,7 @@
 package dsp
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,47 @@ func TestDelayLine_ReadRelativeIntepolation(t *testing.T) {
 	require.Equal(t, 5.5, dl.ReadRelative(0.5))
 	require.Equal(t, 3.7, dl.ReadRelative(0.7))
 }
+
+func TestDelayLine_ReadCubic(t *testing.T) {
+	dl := NewDelayLine(10)
+	for i := 0; i < 10; i++ {
+		dl.TickAbsolute(float64(i*i), 1)
+	}
+	// 0, 81, 64, 49, 36, 25, 16, 9, 4, 1
+	require.Equal(t, 64.0, dl.ReadCubic(2))
+	require.Equal(t, 42.25, dl.ReadCubic(3.5))
+	require.Equal(t, 81.0, dl.ReadCubic(1))
+}
+
+func TestDelayTap(t *testing.T) {
+	// Every interpolation reads a steady sine at a fractional delay.
+	for _, mode := range []Interpolation{InterpolateLinear, InterpolateCubic, InterpolateAllPass} {
+		var (
+			dl    = NewDelayLine(64)
+			tap   = DelayTap{Interpolation: mode}
+			freq  = 0.01
+			delay = 10.3
+		)
+		for i := 0; i < 1000; i++ {
+			dl.Write(math.Sin(2 * math.Pi * freq * float64(i)))
+			out := tap.Read(dl, 1+delay)
+			if i > 500 {
+				require.InDelta(t, math.Sin(2*math.Pi*freq*(float64(i)-delay)), out, 1e-3, "mode %d", mode)
+			}
+		}
+	}
+
+	// Slewed taps glide toward a new delay.
+	var (
+		dl  = NewDelayLine(64)
+		tap = DelayTap{Slew: 10}
+	)
+	for i := 0; i < 64; i++ {
+		dl.Write(float64(i))
+	}
+	require.Equal(t, 62.0, tap.Read(dl, 2))
+	dl.Write(64)
+	// The tap moves a share of the 20 samples toward the new delay, and position p now holds 65-p.
+	moved := 20 * (1 - math.Exp(-0.1))
+	require.InDelta(t, 65-(2+moved), tap.Read(dl, 22), 1e-9)
+}
@@ -0,0 +1,123 @@
+package dsp
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// FFT performs an in-place fast Fourier transform of x. The length of x must be a power of two.
+func FFT(x []complex128) {
+	fft(x, -1)
+}
+
+// IFFT performs an in-place inverse fast Fourier transform of x, including the 1/N scaling. The length of x must be a
+// power of two.
+func IFFT(x []complex128) {
+	fft(x, 1)
+	scale := complex(1/float64(len(x)), 0)
+	for i := range x {
+		x[i] *= scale
+	}
+}
+
+// fft is an iterative radix-2 Cooley-Tukey transform. The sign of the exponent selects the direction.
+func fft(x []complex128, sign float64) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	if !IsPowerOfTwo(n) {
+		panic("dsp: FFT length must be a power of two")
+	}
+
+	shift := 64 - uint(bits.TrailingZeros(uint(n)))
+	for i := range x {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				a, b := x[start+k], w*x[start+k+half]
+				x[start+k], x[start+k+half] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// RealFFT transforms real signals of a fixed length to and from their spectra. A real signal's spectrum is symmetric,
+// so only its first n/2+1 bins are kept, and the transform is done with a complex FFT of half the length.
+type RealFFT struct {
+	n       int
+	packed  []complex128
+	twiddle []complex128
+}
+
+// NewRealFFT returns a new RealFFT for signals of length n, which must be a power of two no less than 2.
+func NewRealFFT(n int) *RealFFT {
+	if n < 2 || !IsPowerOfTwo(n) {
+		panic("dsp: FFT length must be a power of two")
+	}
+	f := &RealFFT{
+		n:       n,
+		packed:  make([]complex128, n/2),
+		twiddle: make([]complex128, n/2+1),
+	}
+	for k := range f.twiddle {
+		f.twiddle[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+	return f
+}
+
+// Size returns the length of the signals that are transformed.
+func (f *RealFFT) Size() int {
+	return f.n
+}
+
+// Forward transforms the signal x into spectrum, which must have room for n/2+1 bins.
+func (f *RealFFT) Forward(x []float64, spectrum []complex128) {
+	// Even samples are packed into the real parts and odd samples into the imaginary parts, and the transforms of the
+	// two are separated out afterwards.
+	m := f.n / 2
+	z := f.packed
+	for k := range z {
+		z[k] = complex(x[2*k], x[2*k+1])
+	}
+	FFT(z)
+	for k := 0; k <= m; k++ {
+		var (
+			a    = z[k%m]
+			b    = cmplx.Conj(z[(m-k)%m])
+			even = (a + b) / 2
+			odd  = (a - b) / complex(0, 2)
+		)
+		spectrum[k] = even + f.twiddle[k]*odd
+	}
+}
+
+// Inverse transforms spectrum, holding n/2+1 bins, back into the signal x.
+func (f *RealFFT) Inverse(spectrum []complex128, x []float64) {
+	m := f.n / 2
+	z := f.packed
+	for k := range z {
+		var (
+			a    = spectrum[k]
+			b    = cmplx.Conj(spectrum[m-k])
+			even = (a + b) / 2
+			odd  = (a - b) / 2 * cmplx.Conj(f.twiddle[k])
+		)
+		z[k] = even + complex(0, 1)*odd
+	}
+	IFFT(z)
+	for k, v := range z {
+		x[2*k], x[2*k+1] = real(v), imag(v)
+	}
+}
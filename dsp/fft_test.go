@@ -0,0 +1,66 @@
+package dsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFFT(t *testing.T) {
+	const n = 64
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*5*float64(i)/n), 0)
+	}
+	orig := append([]complex128(nil), x...)
+
+	FFT(x)
+	for k, v := range x {
+		if k == 5 || k == n-5 {
+			require.InDelta(t, n/2, cmplx.Abs(v), 1e-9)
+		} else {
+			require.InDelta(t, 0, cmplx.Abs(v), 1e-9)
+		}
+	}
+
+	IFFT(x)
+	for i := range x {
+		require.InDelta(t, real(orig[i]), real(x[i]), 1e-9)
+		require.InDelta(t, 0, imag(x[i]), 1e-9)
+	}
+}
+
+func TestFFT_InvalidLength(t *testing.T) {
+	require.Panics(t, func() { FFT(make([]complex128, 12)) })
+}
+
+func TestRealFFT(t *testing.T) {
+	const n = 64
+	var (
+		f        = NewRealFFT(n)
+		x        = make([]float64, n)
+		complexX = make([]complex128, n)
+		spectrum = make([]complex128, n/2+1)
+	)
+	for i := range x {
+		x[i] = math.Sin(2*math.Pi*3*float64(i)/n) + 0.5*math.Cos(2*math.Pi*11*float64(i)/n) + 0.25
+		complexX[i] = complex(x[i], 0)
+	}
+	FFT(complexX)
+
+	f.Forward(x, spectrum)
+	for k, v := range spectrum {
+		require.InDelta(t, real(complexX[k]), real(v), 1e-9, "bin %d", k)
+		require.InDelta(t, imag(complexX[k]), imag(v), 1e-9, "bin %d", k)
+	}
+
+	out := make([]float64, n)
+	f.Inverse(spectrum, out)
+	for i := range x {
+		require.InDelta(t, x[i], out[i], 1e-9)
+	}
+
+	require.Panics(t, func() { NewRealFFT(12) })
+}
@@ -61,6 +61,15 @@ func IsPowerOfTwo(x int) bool {
 	return (x & (x - 1)) == 0
 }
 
+// NextPowerOfTwo returns the smallest power of two that's no less than x
+func NextPowerOfTwo(x int) int {
+	p := 1
+	for p < x {
+		p <<= 1
+	}
+	return p
+}
+
 // Fold reflects a value exceeding minimum/maximum thresholds back over those thresholds
 func Fold(s, min, max float64) float64 {
 	bottomdiff := s - min
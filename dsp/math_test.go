@@ -118,6 +118,12 @@ func TestIsPowerTwo(t *testing.T) {
 	require.False(t, IsPowerOfTwo(5))
 }
 
+func TestNextPowerOfTwo(t *testing.T) {
+	require.Equal(t, 1, NextPowerOfTwo(1))
+	require.Equal(t, 512, NextPowerOfTwo(441))
+	require.Equal(t, 1024, NextPowerOfTwo(1024))
+}
+
 func TestFold(t *testing.T) {
 	tests := []struct {
 		input, min, max, expected float64
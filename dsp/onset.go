@@ -0,0 +1,114 @@
+package dsp
+
+import "math"
+
+const (
+	// onsetHistory is the number of frames of spectral flux that an onset is measured against.
+	onsetHistory = 8
+	// onsetBandWidth is the number of frequencies grouped into each band whose level is measured. Grouping them steadies
+	// the levels of noisy sounds, whose frequencies vary wildly from frame to frame.
+	onsetBandWidth = 8
+)
+
+// OnsetDetector finds the onsets of notes and hits in a signal from its spectral flux: how much the levels of its
+// frequency bands rise from one frame to the next, measured on a log scale so that quiet parts of the spectrum count as
+// much as loud ones. An onset is a frame whose flux rises well above that of the frames before it.
+type OnsetDetector struct {
+	size, hop     int
+	fft           *RealFFT
+	window, input []float64
+	frame         []float64
+	spectrum      []complex128
+	levels        []float64 // log levels of the bands of the previous frame
+	history       []float64 // flux of recent frames
+	historyPos    int
+	count         int
+	wasAbove      bool
+}
+
+// NewOnsetDetector returns a new OnsetDetector that analyzes frames of size samples every hop samples. The size must be
+// a power of two.
+func NewOnsetDetector(size, hop int) *OnsetDetector {
+	if hop < 1 || hop > size {
+		panic("dsp: onset detector hop must be between 1 and its size")
+	}
+	d := &OnsetDetector{
+		size:     size,
+		hop:      hop,
+		fft:      NewRealFFT(size),
+		window:   make([]float64, size),
+		input:    make([]float64, size),
+		frame:    make([]float64, size),
+		spectrum: make([]complex128, size/2+1),
+		levels:   make([]float64, size/2/onsetBandWidth),
+		history:  make([]float64, onsetHistory),
+	}
+	for i := range d.window {
+		d.window[i] = Hann(float64(i) / float64(size))
+	}
+	return d
+}
+
+// Size returns the number of samples in a frame. An onset is found within a frame of the sample where it starts.
+func (d *OnsetDetector) Size() int {
+	return d.size
+}
+
+// Tick adds a sample to the signal. When the sample completes a frame that holds an onset, it returns true along with
+// the onset's strength. Sensitivity ranges from 0 to 1; higher values find quieter onsets.
+func (d *OnsetDetector) Tick(in, sensitivity float64) (onset bool, strength float64) {
+	d.input[d.size-d.hop+d.count] = in
+	d.count++
+	if d.count < d.hop {
+		return false, 0
+	}
+	d.count = 0
+
+	flux := d.flux()
+	copy(d.input, d.input[d.hop:])
+
+	var mean float64
+	for _, v := range d.history {
+		mean += v
+	}
+	mean /= onsetHistory
+	d.history[d.historyPos] = flux
+	d.historyPos = (d.historyPos + 1) % onsetHistory
+
+	// Lower sensitivities need the flux to rise further above recent frames, and further above nothing at all.
+	var (
+		s         = 1 - Clamp(sensitivity, 0, 1)
+		threshold = mean*(1.5+2.5*s) + 0.005*math.Pow(40, s)
+	)
+	above := flux > threshold
+	onset = above && !d.wasAbove
+	d.wasAbove = above
+	if onset {
+		return true, flux
+	}
+	return false, 0
+}
+
+func (d *OnsetDetector) flux() float64 {
+	for i, v := range d.input {
+		d.frame[i] = v * d.window[i]
+	}
+	d.fft.Forward(d.frame, d.spectrum)
+
+	var (
+		scale = 4 / float64(d.size)
+		flux  float64
+	)
+	for b := range d.levels {
+		var power float64
+		for _, v := range d.spectrum[b*onsetBandWidth : (b+1)*onsetBandWidth] {
+			power += real(v)*real(v) + imag(v)*imag(v)
+		}
+		level := math.Log1p(100 * math.Sqrt(power) * scale)
+		if rise := level - d.levels[b]; rise > 0 {
+			flux += rise
+		}
+		d.levels[b] = level
+	}
+	return flux / float64(len(d.levels))
+}
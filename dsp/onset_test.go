@@ -0,0 +1,54 @@
+package dsp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// onsetSignal is a sustained tone with noisy hits of decreasing level, the last of which lands on the tail of the one
+// before it.
+func onsetSignal(n int, r *rand.Rand) float64 {
+	x := 0.001*(r.Float64()*2-1) + 0.3*math.Sin(2*math.Pi*220*float64(n)/sampleRate)*math.Min(1, float64(n)/2000)
+	for _, hit := range []struct {
+		at  int
+		amp float64
+	}{{10000, 0.5}, {30000, 0.05}, {50000, 0.01}, {70000, 0.5}, {72000, 0.3}} {
+		if n >= hit.at {
+			x += hit.amp * (r.Float64()*2 - 1) * math.Exp(-float64(n-hit.at)/4000)
+		}
+	}
+	return x
+}
+
+func TestOnsetDetector(t *testing.T) {
+	tests := []struct {
+		sensitivity float64
+		hits        []int
+	}{
+		{0, []int{10000, 30000, 70000}},
+		{0.5, []int{10000, 30000, 50000, 70000}},
+		{0.8, []int{10000, 30000, 50000, 70000, 72000}},
+	}
+	for _, test := range tests {
+		var (
+			r      = rand.New(rand.NewSource(1))
+			d      = NewOnsetDetector(512, 128)
+			onsets []int
+		)
+		for n := 5000; n < 100000; n++ {
+			// Skip the fade in of the tone, which counts as an onset.
+			if on, strength := d.Tick(onsetSignal(n, r), test.sensitivity); on && n > 6000 {
+				require.True(t, strength > 0)
+				onsets = append(onsets, n)
+			}
+		}
+
+		require.Len(t, onsets, len(test.hits), "sensitivity %f found %v", test.sensitivity, onsets)
+		for i, hit := range test.hits {
+			require.True(t, onsets[i] >= hit && onsets[i] < hit+d.Size(), "sensitivity %f found %v", test.sensitivity, onsets)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package dsp
+
+// STFT processes a signal in the frequency domain with a short-time Fourier transform. The signal is cut into
+// overlapping frames, each of which is windowed and transformed, handed to a process function that changes its
+// spectrum in place, transformed back, windowed again and added to the output.
+//
+// The output is delayed by the size of a frame. When the process function leaves the spectra as they are, the output is
+// the input delayed, whatever the window or overlap.
+type STFT struct {
+	size, hop    int
+	fft          *RealFFT
+	window, norm []float64
+	input        []float64 // the most recent frame of input
+	output       []float64 // output being added to by overlapping frames
+	frame        []float64
+	spectrum     []complex128
+	process      func(spectrum []complex128)
+	count        int
+}
+
+// NewSTFT returns a new STFT with frames of size samples, overlap frames sounding at once and a window function
+// defined on [0, 1]. The size must be a power of two and a multiple of overlap. The process function is given the n/2+1
+// bins of each frame's spectrum, from DC up to Nyquist.
+func NewSTFT(size, overlap int, window func(float64) float64, process func(spectrum []complex128)) *STFT {
+	if overlap < 1 || size%overlap != 0 {
+		panic("dsp: STFT size must be a multiple of its overlap")
+	}
+	s := &STFT{
+		size:     size,
+		hop:      size / overlap,
+		fft:      NewRealFFT(size),
+		window:   make([]float64, size),
+		norm:     make([]float64, size),
+		input:    make([]float64, size),
+		output:   make([]float64, size),
+		frame:    make([]float64, size),
+		spectrum: make([]complex128, size/2+1),
+		process:  process,
+	}
+	for i := range s.window {
+		s.window[i] = window(float64(i) / float64(size))
+	}
+
+	// The window is applied twice to every frame, so each output sample is divided by the sum of the squared window
+	// over the frames that overlap it.
+	for i := range s.norm {
+		var sum float64
+		for j := i % s.hop; j < size; j += s.hop {
+			sum += s.window[j] * s.window[j]
+		}
+		if sum > 1e-9 {
+			s.norm[i] = s.window[i] / sum
+		}
+	}
+	return s
+}
+
+// Size returns the number of samples in a frame, which is also the delay of the output.
+func (s *STFT) Size() int {
+	return s.size
+}
+
+// Hop returns the number of samples between the starts of frames.
+func (s *STFT) Hop() int {
+	return s.hop
+}
+
+// Tick advances the operation
+func (s *STFT) Tick(in float64) float64 {
+	s.input[s.size-s.hop+s.count] = in
+	out := s.output[s.count]
+	s.count++
+	if s.count == s.hop {
+		s.count = 0
+		s.processFrame()
+	}
+	return out
+}
+
+func (s *STFT) processFrame() {
+	for i, v := range s.input {
+		s.frame[i] = v * s.window[i]
+	}
+	copy(s.input, s.input[s.hop:])
+
+	s.fft.Forward(s.frame, s.spectrum)
+	if s.process != nil {
+		s.process(s.spectrum)
+	}
+	s.fft.Inverse(s.spectrum, s.frame)
+
+	copy(s.output, s.output[s.hop:])
+	for i := s.size - s.hop; i < s.size; i++ {
+		s.output[i] = 0
+	}
+	for i, v := range s.frame {
+		s.output[i] += v * s.norm[i]
+	}
+}
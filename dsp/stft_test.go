@@ -0,0 +1,76 @@
+package dsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSTFT_Identity(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlap int
+		window  func(float64) float64
+	}{
+		{"hann", 4, Hann},
+		{"hann-2", 2, Hann},
+		{"blackman", 8, Blackman},
+		{"rectangular", 1, func(float64) float64 { return 1 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			const size = 256
+			var (
+				s     = NewSTFT(size, test.overlap, test.window, nil)
+				input = make([]float64, 4000)
+			)
+			for i := range input {
+				input[i] = math.Sin(float64(i)*0.05) + 0.3*math.Sin(float64(i)*0.71)
+			}
+			for i, v := range input {
+				out := s.Tick(v)
+				if i >= size {
+					require.InDelta(t, input[i-size], out, 1e-9, "sample %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestSTFT_Process(t *testing.T) {
+	// Removing the bins around a sine removes it, leaving the offset.
+	s := NewSTFT(64, 4, Hann, func(spectrum []complex128) {
+		for k := 4; k < len(spectrum); k++ {
+			spectrum[k] = 0
+		}
+	})
+	var out float64
+	for i := 0; i < 1000; i++ {
+		out = s.Tick(0.5 + math.Sin(2*math.Pi*float64(i)/8))
+	}
+	require.InDelta(t, 0.5, out, 1e-9)
+
+	// The process function sees the spectrum of each windowed frame.
+	var peak int
+	s = NewSTFT(64, 4, Hann, func(spectrum []complex128) {
+		peak = 0
+		for k, v := range spectrum {
+			if cmplx.Abs(v) > cmplx.Abs(spectrum[peak]) {
+				peak = k
+			}
+		}
+	})
+	for i := 0; i < 200; i++ {
+		s.Tick(math.Sin(2 * math.Pi * 5 * float64(i) / 64))
+	}
+	require.Equal(t, 5, peak)
+	require.Equal(t, 64, s.Size())
+	require.Equal(t, 16, s.Hop())
+}
+
+func TestSTFT_InvalidOverlap(t *testing.T) {
+	require.Panics(t, func() { NewSTFT(64, 3, Hann, nil) })
+	require.Panics(t, func() { NewSTFT(100, 4, Hann, nil) })
+}
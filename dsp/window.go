@@ -0,0 +1,42 @@
+package dsp
+
+import "math"
+
+// Hann returns the value of a Hann (raised cosine) window at position x [0, 1].
+func Hann(x float64) float64 {
+	return 0.5 - 0.5*math.Cos(2*math.Pi*x)
+}
+
+// Triangle returns the value of a triangular window at position x [0, 1].
+func Triangle(x float64) float64 {
+	return 1 - math.Abs(2*x-1)
+}
+
+// Tukey returns the value of a Tukey (tapered cosine) window at position x [0, 1]. The ratio controls the portion of
+// the window that's tapered: 0 is rectangular and 1 is a Hann window.
+func Tukey(x, ratio float64) float64 {
+	if ratio <= 0 {
+		return 1
+	}
+	edge := ratio / 2
+	switch {
+	case x < edge:
+		return Hann(x / ratio)
+	case x > 1-edge:
+		return Hann((x - 1 + ratio) / ratio)
+	}
+	return 1
+}
+
+// Gaussian returns the value of a Gaussian window at position x [0, 1]. Sigma is the standard deviation relative to
+// half the window's width.
+func Gaussian(x, sigma float64) float64 {
+	d := (2*x - 1) / sigma
+	return math.Exp(-0.5 * d * d)
+}
+
+// Blackman returns the value of a Blackman window at position x [0, 1]. Its sidelobes are far lower than a Hann
+// window's, at the cost of a wider main lobe.
+func Blackman(x float64) float64 {
+	return 0.42 - 0.5*math.Cos(2*math.Pi*x) + 0.08*math.Cos(4*math.Pi*x)
+}
@@ -0,0 +1,33 @@
+package dsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		name   string
+		window func(float64) float64
+		edge   float64
+	}{
+		{"hann", Hann, 0},
+		{"triangle", Triangle, 0},
+		{"tukey", func(x float64) float64 { return Tukey(x, 0.5) }, 0},
+		{"gaussian", func(x float64) float64 { return Gaussian(x, 0.4) }, 0.0439},
+		{"blackman", Blackman, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.InDelta(t, test.edge, test.window(0), 1e-3)
+			require.InDelta(t, test.edge, test.window(1), 1e-3)
+			require.InDelta(t, 1, test.window(0.5), 1e-9)
+			require.InDelta(t, test.window(0.2), test.window(0.8), 1e-9)
+		})
+	}
+
+	require.Equal(t, 1.0, Tukey(0.1, 0))
+	require.Equal(t, 1.0, Tukey(0.3, 0.5))
+	require.InDelta(t, Hann(0.1), Tukey(0.1, 1), 1e-9)
+}
@@ -0,0 +1,131 @@
+package dsp
+
+import "math"
+
+// PitchDetector estimates the period of a monophonic signal with the YIN algorithm, from "YIN, a fundamental frequency
+// estimator for speech and music" by de Cheveigné and Kawahara. The difference function at the heart of YIN is
+// computed from an autocorrelation done in the frequency domain.
+type PitchDetector struct {
+	minPeriod, maxPeriod int
+	window               int
+	fft                  *RealFFT
+	head, whole          []float64
+	headSpec, wholeSpec  []complex128
+	energy, diff         []float64
+}
+
+// NewPitchDetector returns a new PitchDetector for periods between minPeriod and maxPeriod samples.
+func NewPitchDetector(minPeriod, maxPeriod int) *PitchDetector {
+	if minPeriod < 2 || maxPeriod <= minPeriod {
+		panic("dsp: pitch detector periods must be at least 2 samples and in order")
+	}
+	var (
+		window = maxPeriod
+		size   = NextPowerOfTwo(window + maxPeriod)
+	)
+	return &PitchDetector{
+		minPeriod: minPeriod,
+		maxPeriod: maxPeriod,
+		window:    window,
+		fft:       NewRealFFT(size),
+		head:      make([]float64, size),
+		whole:     make([]float64, size),
+		headSpec:  make([]complex128, size/2+1),
+		wholeSpec: make([]complex128, size/2+1),
+		energy:    make([]float64, window+maxPeriod+1),
+		diff:      make([]float64, maxPeriod+2),
+	}
+}
+
+// Size returns the number of samples that Detect analyzes: twice the longest period.
+func (p *PitchDetector) Size() int {
+	return p.window + p.maxPeriod
+}
+
+// Detect estimates the period, in samples, of the signal in x, which must hold Size samples with the oldest first.
+// Confidence ranges from 0 to 1 and is how closely the signal repeats at that period. Threshold is the largest
+// normalized difference, between 0 and 1, at which a period is taken before a shorter one; lower values are less
+// likely to mistake a harmonic for the fundamental.
+func (p *PitchDetector) Detect(x []float64, threshold float64) (period, confidence float64) {
+	// The difference at each lag is the energy of the two windows less twice their correlation.
+	n := p.Size()
+	copy(p.whole, x[:n])
+	copy(p.head, x[:p.window])
+	for i := p.window; i < len(p.head); i++ {
+		p.head[i] = 0
+		if i >= n {
+			p.whole[i] = 0
+		}
+	}
+	p.fft.Forward(p.head, p.headSpec)
+	p.fft.Forward(p.whole, p.wholeSpec)
+	for k := range p.wholeSpec {
+		a := p.headSpec[k]
+		p.wholeSpec[k] *= complex(real(a), -imag(a))
+	}
+	p.fft.Inverse(p.wholeSpec, p.whole)
+	correlation := p.whole
+
+	p.energy[0] = 0
+	for i := 0; i < n; i++ {
+		p.energy[i+1] = p.energy[i] + x[i]*x[i]
+	}
+	windowEnergy := func(lag int) float64 {
+		return p.energy[lag+p.window] - p.energy[lag]
+	}
+	if windowEnergy(0) < 1e-12 {
+		return 0, 0
+	}
+
+	// The cumulative mean normalized difference divides each lag's difference by the mean of those at shorter lags,
+	// so that it starts at 1 and only dips where the signal repeats.
+	var (
+		d   = p.diff
+		sum float64
+	)
+	d[0] = 1
+	for lag := 1; lag <= p.maxPeriod; lag++ {
+		v := math.Max(windowEnergy(0)+windowEnergy(lag)-2*correlation[lag], 0)
+		sum += v
+		if sum > 0 {
+			d[lag] = v * float64(lag) / sum
+		} else {
+			d[lag] = 1
+		}
+	}
+
+	// Take the first dip below the threshold, or failing that the deepest dip.
+	best := -1
+	for lag := p.minPeriod; lag <= p.maxPeriod; lag++ {
+		if d[lag] < threshold {
+			for lag < p.maxPeriod && d[lag+1] < d[lag] {
+				lag++
+			}
+			best = lag
+			break
+		}
+	}
+	if best < 0 {
+		best = p.minPeriod
+		for lag := p.minPeriod; lag <= p.maxPeriod; lag++ {
+			if d[lag] < d[best] {
+				best = lag
+			}
+		}
+	}
+
+	// Refine the period between samples with a parabola through the dip.
+	period = float64(best)
+	if best > p.minPeriod && best < p.maxPeriod {
+		var (
+			a     = d[best-1]
+			b     = d[best]
+			c     = d[best+1]
+			curve = a - 2*b + c
+		)
+		if curve > 0 {
+			period += 0.5 * (a - c) / curve
+		}
+	}
+	return period, Clamp(1-d[best], 0, 1)
+}
@@ -0,0 +1,42 @@
+package dsp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPitchDetector(t *testing.T) {
+	p := NewPitchDetector(20, 800)
+	x := make([]float64, p.Size())
+
+	for _, freq := range []float64{60, 110, 261.63, 440, 1000, 1800} {
+		// A sawtooth-like tone with strong harmonics
+		period := sampleRate / freq
+		for i := range x {
+			phase := 2 * math.Pi * float64(i) / period
+			x[i] = math.Sin(phase) + 0.5*math.Sin(2*phase) + 0.33*math.Sin(3*phase)
+		}
+		detected, confidence := p.Detect(x, 0.15)
+		require.InEpsilon(t, period, detected, 0.002, "%f Hz", freq)
+		require.True(t, confidence > 0.95, "%f Hz confidence %f", freq, confidence)
+	}
+}
+
+func TestPitchDetector_Unpitched(t *testing.T) {
+	p := NewPitchDetector(20, 800)
+	x := make([]float64, p.Size())
+
+	_, confidence := p.Detect(x, 0.15)
+	require.Equal(t, 0.0, confidence)
+
+	// Noise doesn't repeat.
+	state := uint32(1)
+	for i := range x {
+		state = state*1664525 + 1013904223
+		x[i] = float64(state)/math.MaxUint32*2 - 1
+	}
+	_, confidence = p.Detect(x, 0.15)
+	require.True(t, confidence < 0.5, "confidence %f", confidence)
+}
@@ -0,0 +1,194 @@
+package dsp
+
+import "math"
+
+// ZDFModels for ZDFFilter
+const (
+	// Ladder is a four-pole transistor ladder in the style of Moog.
+	Ladder ZDFModel = iota
+	// DiodeLadder is a four-pole diode ladder in the style of the TB-303, whose stages load one another.
+	DiodeLadder
+	// SallenKey is a two-pole Sallen-Key filter in the style of the MS-20, with saturation in its feedback path.
+	SallenKey
+)
+
+// ZDFModel describes the circuit that a ZDFFilter models.
+type ZDFModel int
+
+// Feedback at which each model starts to oscillate on its own. Resonance is scaled by zdfResonanceScale so that
+// every model oscillates a little below a resonance of 1.
+const (
+	ladderSelfOscillation    = 4
+	diodeSelfOscillation     = 17
+	sallenKeySelfOscillation = 3
+	zdfResonanceScale        = 1.1
+)
+
+const (
+	zdfMaxCutoff = 0.49
+	// diodeTuning is the ratio of a diode ladder's tuning to the frequency it resonates at.
+	diodeTuning = math.Sqrt2
+	// sallenKeyDamping is the damping of a Sallen-Key filter with equal resistors and capacitors.
+	sallenKeyDamping = 3
+)
+
+// ZDFFilter is a zero-delay feedback model of an analog filter. Its integrators are discretized with the trapezoidal
+// rule and its feedback loop is solved within each sample, so it stays in tune and stable as resonance and cutoff are
+// modulated. Both its input and its feedback saturate; the feedback is approximated by scaling the loop by the gain of a
+// tanh curve at the previous sample.
+//
+// Cutoff is the frequency at which the filter resonates, Resonance is in the range [0, 1] and Drive sets how hard
+// the filter saturates without changing the level of quiet signals.
+type ZDFFilter struct {
+	Model                    ZDFModel
+	Cutoff, Resonance, Drive float64
+
+	states, y [4]float64
+	feedback  float64 // signal fed back at the previous sample
+}
+
+// Tick advances the operation
+func (f *ZDFFilter) Tick(in float64) (lp, bp, hp float64) {
+	var (
+		g     = Tan(math.Pi * Clamp(math.Abs(f.Cutoff), 0, zdfMaxCutoff))
+		res   = Clamp(f.Resonance, 0, 1) * zdfResonanceScale
+		drive = math.Max(f.Drive, 0.01)
+		a, k  float64
+		n     = 4
+		m     zdfMatrix
+		b     = [4]float64{1, 0, 0, 0}
+	)
+
+	switch f.Model {
+	case DiodeLadder:
+		k = res * diodeSelfOscillation
+		g *= diodeTuning
+		in = saturate(in*(1+k), drive) // make up for the level lost to resonance
+		m = zdfMatrix{
+			{-1.5, 0.5, 0, 0},
+			{0.5, -1, 0.5, 0},
+			{0, 0.5, -1, 0.5},
+			{0, 0, 0.5, -0.5},
+		}
+	case SallenKey:
+		k = res * sallenKeySelfOscillation
+		n = 2
+		in = saturate(in, drive)
+		m = zdfMatrix{
+			{0, 1},
+			{-1, -sallenKeyDamping},
+		}
+		b = [4]float64{0, 1}
+	default:
+		k = res * ladderSelfOscillation
+		in = saturate(in*(1+k), drive)
+		m = zdfMatrix{
+			{-1, 0, 0, 0},
+			{1, -1, 0, 0},
+			{0, 1, -1, 0},
+			{0, 0, 1, -1},
+		}
+	}
+
+	// The feedback is solved with the saturation's gain at the previous sample and then solved again with its gain
+	// midway between the previous sample and that first estimate, which keeps the filter in tune when it resonates at
+	// high frequencies.
+	var (
+		loop    = m
+		fb      = f.feedback
+		row, to = 0, 3 // the feedback enters row from column to
+		sign    = -1.0
+	)
+	if f.Model == SallenKey {
+		row, to, sign = 1, 1, 1 // feedback counteracts the damping
+	}
+	for pass := 0; pass < 2; pass++ {
+		a = saturationGain(fb, drive)
+		loop[row][to] = m[row][to] + sign*a*k
+		f.solve(n, g, &loop, &b, in)
+		fb = (f.y[to] + f.feedback) / 2
+	}
+	f.commit(n)
+	f.feedback = f.y[to]
+
+	if f.Model == SallenKey {
+		lp, bp = f.y[0], f.y[1]
+		return lp, bp, in - lp - (sallenKeyDamping-a*k)*bp
+	}
+	return f.ladderOutputs(in - a*k*f.y[3])
+}
+
+// ladderOutputs mixes the stages of a ladder, driven by u, into lowpass, bandpass and highpass responses.
+func (f *ZDFFilter) ladderOutputs(u float64) (lp, bp, hp float64) {
+	y := f.y
+	lp = y[3]
+	bp = 4*y[1] - 8*y[2] + 4*y[3]
+	hp = u - 4*y[0] + 6*y[1] - 4*y[2] + y[3]
+	return
+}
+
+type zdfMatrix [4][4]float64
+
+// solve finds the outputs of the system dy/dt = m*y + b*in at the next sample and stores them in f.y. The first n rows and
+// columns of m are used; g is the prewarped gain of the integrators.
+func (f *ZDFFilter) solve(n int, g float64, m *zdfMatrix, b *[4]float64, in float64) {
+	// Each integrator outputs g*(its input) + its state, so the outputs satisfy (I - g*m)*y = g*b*in + states.
+	var (
+		lhs zdfMatrix
+		rhs [4]float64
+	)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			lhs[r][c] = -g * m[r][c]
+		}
+		lhs[r][r]++
+		rhs[r] = g*b[r]*in + f.states[r]
+	}
+
+	// Gaussian elimination with partial pivoting
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(lhs[r][c]) > math.Abs(lhs[p][c]) {
+				p = r
+			}
+		}
+		lhs[c], lhs[p] = lhs[p], lhs[c]
+		rhs[c], rhs[p] = rhs[p], rhs[c]
+		for r := c + 1; r < n; r++ {
+			factor := lhs[r][c] / lhs[c][c]
+			for k := c; k < n; k++ {
+				lhs[r][k] -= factor * lhs[c][k]
+			}
+			rhs[r] -= factor * rhs[c]
+		}
+	}
+	for r := n - 1; r >= 0; r-- {
+		v := rhs[r]
+		for c := r + 1; c < n; c++ {
+			v -= lhs[r][c] * f.y[c]
+		}
+		f.y[r] = v / lhs[r][r]
+	}
+}
+
+// commit moves the integrators' states on to the outputs found by solve.
+func (f *ZDFFilter) commit(n int) {
+	for r := 0; r < n; r++ {
+		f.states[r] = 2*f.y[r] - f.states[r]
+	}
+}
+
+// saturate is a tanh curve whose slope at zero is 1 regardless of drive.
+func saturate(x, drive float64) float64 {
+	return math.Tanh(drive*x) / drive
+}
+
+// saturationGain returns the gain that saturate applies to x.
+func saturationGain(x, drive float64) float64 {
+	v := drive * x
+	if math.Abs(v) < 1e-6 {
+		return 1
+	}
+	return math.Tanh(v) / v
+}
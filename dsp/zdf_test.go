@@ -0,0 +1,77 @@
+package dsp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var zdfModels = map[string]ZDFModel{
+	"ladder":     Ladder,
+	"diode":      DiodeLadder,
+	"sallen-key": SallenKey,
+}
+
+func TestZDFFilter_PassesDC(t *testing.T) {
+	for name, model := range zdfModels {
+		for _, res := range []float64{0, 0.5} {
+			f := &ZDFFilter{Model: model, Cutoff: Frequency(1000, sampleRate).Float64(), Resonance: res, Drive: 1}
+			var lp, bp, hp float64
+			for i := 0; i < sampleRate; i++ {
+				lp, bp, hp = f.Tick(0.001)
+			}
+			require.InEpsilon(t, 0.001, lp, 1e-4, "%s at resonance %g", name, res)
+			require.InDelta(t, 0, bp, 1e-8, "%s at resonance %g", name, res)
+			require.InDelta(t, 0, hp, 1e-8, "%s at resonance %g", name, res)
+		}
+	}
+}
+
+func TestZDFFilter_SelfOscillation(t *testing.T) {
+	for name, model := range zdfModels {
+		for _, hz := range []float64{110, 1000, 5000} {
+			f := &ZDFFilter{Model: model, Cutoff: Frequency(hz, sampleRate).Float64(), Resonance: 1, Drive: 1}
+			f.Tick(1)
+
+			var (
+				out              = make([]float64, sampleRate)
+				peak             float64
+				crossings, first int
+				lastCrossing     int
+				last             float64
+			)
+			for i := range out {
+				out[i], _, _ = f.Tick(0)
+			}
+			for i, v := range out[sampleRate/2:] {
+				peak = math.Max(peak, math.Abs(v))
+				if last <= 0 && v > 0 {
+					if crossings == 0 {
+						first = i
+					}
+					crossings++
+					lastCrossing = i
+				}
+				last = v
+			}
+			require.True(t, peak > 0.05 && peak < 2, "%s at %gHz peaks at %g", name, hz, peak)
+
+			// The filter resonates within a few percent of its cutoff.
+			measured := float64(crossings-1) * sampleRate / float64(lastCrossing-first)
+			require.InEpsilon(t, hz, measured, 0.05, "%s at %gHz", name, hz)
+		}
+	}
+}
+
+func TestZDFFilter_Decays(t *testing.T) {
+	for name, model := range zdfModels {
+		f := &ZDFFilter{Model: model, Cutoff: Frequency(1000, sampleRate).Float64(), Resonance: 0.8, Drive: 1}
+		f.Tick(1)
+		var lp float64
+		for i := 0; i < sampleRate; i++ {
+			lp, _, _ = f.Tick(0)
+		}
+		require.InDelta(t, 0, lp, 1e-6, name)
+	}
+}
@@ -24,6 +24,18 @@ func UnmountUnit(u *unit.Unit) func(*Graph) error {
 	}
 }
 
+// SetSmoothing sets the default number of samples constant values are ramped over when patched into inputs. Zero
+// disables smoothing.
+func SetSmoothing(samples int) func(*Graph) error {
+	return func(g *Graph) error {
+		if samples < 0 {
+			return errors.Errorf("smoothing cannot be negative")
+		}
+		g.smoothing = samples
+		return nil
+	}
+}
+
 // EmitOutputs sinks 1 or 2 outputs to the Engine.
 func EmitOutputs(left, right unit.OutRef) func(*Graph) error {
 	return func(g *Graph) error {
@@ -49,10 +61,25 @@ func EmitOutputs(left, right unit.OutRef) func(*Graph) error {
 }
 
 // PatchInput patches values into a Unit's Ins. If `forceReset` is set to `true` all Ins on that Unit that haven't been
-// referenced in `inputs` will be reset to their default values.
+// referenced in `inputs` will be reset to their default values. Constant values are smoothed over the Graph's default
+// smoothing time.
 func PatchInput(u *unit.Unit, inputs map[string]any, forceReset bool) func(*Graph) error {
+	return patchInput(u, inputs, forceReset, nil)
+}
+
+// PatchInputSmoothed behaves like PatchInput, but constant values are ramped to over a specific number of samples
+// instead of the Graph's default smoothing time.
+func PatchInputSmoothed(u *unit.Unit, inputs map[string]any, forceReset bool, samples int) func(*Graph) error {
+	return patchInput(u, inputs, forceReset, &samples)
+}
+
+func patchInput(u *unit.Unit, inputs map[string]any, forceReset bool, samples *int) func(*Graph) error {
 	seen := make(map[string]struct{}, len(u.In))
 	return func(g *Graph) error {
+		smoothing := g.smoothing
+		if samples != nil {
+			smoothing = *samples
+		}
 		for k, v := range inputs {
 			in, ok := u.In[k]
 			if !ok {
@@ -67,7 +94,7 @@ func PatchInput(u *unit.Unit, inputs map[string]any, forceReset bool) func(*Grap
 			}
 			seen[k] = struct{}{}
 
-			if err := g.Patch(v, in); err != nil {
+			if err := g.PatchSmoothed(v, in, smoothing); err != nil {
 				return err
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/randtest"
 	"github.com/brettbuddin/shaden/unit"
 	"github.com/stretchr/testify/require"
 )
@@ -182,3 +183,68 @@ func TestSwapUnit_SourceInput(t *testing.T) {
 	require.False(t, unit2.In["in"].HasSource())
 	require.Equal(t, 0, unit2.Out["out"].Out().DestinationCount())
 }
+
+func TestPatchInputSmoothed(t *testing.T) {
+	var (
+		g  = NewGraph(frameSize)
+		io = unit.NewIO("dummy", frameSize)
+	)
+	io.NewIn("in", dsp.Float64(0))
+	u := unit.NewUnit(io, nil)
+	require.NoError(t, g.Mount(u))
+
+	fn := PatchInputSmoothed(u, map[string]interface{}{"in": 1.0}, false, frameSize*2)
+	require.NoError(t, fn(g))
+
+	in := io.In["in"]
+	require.Equal(t, 0.0, in.Read(0))
+
+	u.AdvanceRamps(frameSize)
+	require.Equal(t, 1.0/float64(frameSize*2), in.Read(0))
+	require.Equal(t, 0.5, in.Read(frameSize-1))
+
+	u.AdvanceRamps(frameSize)
+	require.Equal(t, 1.0, in.Read(frameSize-1))
+
+	u.AdvanceRamps(frameSize)
+	require.Equal(t, 1.0, in.Read(0))
+	require.Equal(t, dsp.Float64(1), in.Constant())
+}
+
+func TestPatchInputSmoothed_OutputProcessors(t *testing.T) {
+	// gen is processed through its outputs rather than as a whole, but its inputs still ramp.
+	g := NewGraph(frameSize)
+	u, err := unit.Builders()["gen"](unit.Config{Rand: randtest.Static(), SampleRate: sampleRate, FrameSize: frameSize})
+	require.NoError(t, err)
+	require.NoError(t, g.Mount(u))
+
+	freq := dsp.Frequency(1, sampleRate)
+	require.NoError(t, PatchInputSmoothed(u, map[string]interface{}{"freq": freq}, false, frameSize)(g))
+	g.Sort()
+	for i := 0; i < 10; i++ {
+		for _, p := range g.Processors() {
+			p.ProcessFrame(frameSize)
+		}
+	}
+	require.Equal(t, freq.Float64(), u.In["freq"].Read(0))
+	require.Equal(t, freq.Float64(), u.In["freq"].Read(frameSize-1))
+}
+
+func TestSetSmoothing(t *testing.T) {
+	var (
+		g  = NewGraph(frameSize)
+		io = unit.NewIO("dummy", frameSize)
+	)
+	io.NewIn("in", dsp.Float64(0))
+	u := unit.NewUnit(io, nil)
+	require.NoError(t, g.Mount(u))
+
+	require.NoError(t, SetSmoothing(frameSize)(g))
+	require.Equal(t, frameSize, g.Smoothing())
+	require.Error(t, SetSmoothing(-1)(g))
+
+	require.NoError(t, PatchInput(u, map[string]interface{}{"in": 2.0}, false)(g))
+	u.AdvanceRamps(frameSize)
+	require.Equal(t, 1.0, io.In["in"].Read(frameSize/2-1))
+	require.Equal(t, 2.0, io.In["in"].Read(frameSize-1))
+}
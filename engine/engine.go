@@ -40,6 +40,22 @@ func WithFadeIn(ms int) Option {
 	}
 }
 
+// WithSmoothing sets the default duration (milliseconds) of the ramp used when constant values are patched into inputs.
+func WithSmoothing(ms int) Option {
+	return func(e *Engine) {
+		e.graph.smoothing = ms * e.backend.SampleRate() / 1000
+	}
+}
+
+// WithControlPeriod sets the number of samples between reads of slowly changing unit inputs.
+func WithControlPeriod(n int) Option {
+	return func(e *Engine) {
+		if n > 0 {
+			e.controlPeriod = n
+		}
+	}
+}
+
 // WithGain sets the global gain for all samples written to the output
 func WithGain(gain float32) Option {
 	return func(e *Engine) {
@@ -49,27 +65,29 @@ func WithGain(gain float32) Option {
 
 // Engine is the connection of the synthesizer to PortAudio
 type Engine struct {
-	messages     MessageChannel
-	backend      Backend
-	graph        *Graph
-	errors, stop chan error
-	chunks       int
-	fadeIn       int
-	frameSize    int
-	gain         float32
+	messages      MessageChannel
+	backend       Backend
+	graph         *Graph
+	errors, stop  chan error
+	chunks        int
+	fadeIn        int
+	frameSize     int
+	controlPeriod int
+	gain          float32
 }
 
 // New returns a new Sink
 func New(backend Backend, frameSize int, opts ...Option) (*Engine, error) {
 	e := &Engine{
-		backend:   backend,
-		messages:  newMessageChannel(),
-		graph:     NewGraph(frameSize),
-		errors:    make(chan error),
-		stop:      make(chan error),
-		chunks:    int(backend.FrameSize() / frameSize),
-		frameSize: frameSize,
-		gain:      1,
+		backend:       backend,
+		messages:      newMessageChannel(),
+		graph:         NewGraph(frameSize),
+		errors:        make(chan error),
+		stop:          make(chan error),
+		chunks:        int(backend.FrameSize() / frameSize),
+		frameSize:     frameSize,
+		controlPeriod: unit.DefaultControlPeriod,
+		gain:          1,
 	}
 
 	for _, opt := range opts {
@@ -85,6 +103,9 @@ func (e *Engine) SampleRate() int { return e.backend.SampleRate() }
 // FrameSize returns the frame size
 func (e *Engine) FrameSize() int { return e.frameSize }
 
+// ControlPeriod returns the number of samples between reads of slowly changing unit inputs.
+func (e *Engine) ControlPeriod() int { return e.controlPeriod }
+
 // UnitBuilders returns all unit.Builders for Units provided by the Engine.
 func (e *Engine) UnitBuilders() map[string]unit.Builder {
 	return unit.PrepareBuilders(map[string]unit.IOBuilder{
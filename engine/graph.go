@@ -24,6 +24,7 @@ func NewGraph(frameSize int) *Graph {
 // Graph is a graph of units.
 type Graph struct {
 	singleSampleDisabled  bool
+	smoothing             int
 	graph                 *graph.Graph
 	processors            []unit.FrameProcessor
 	sink                  *unit.Unit
@@ -78,7 +79,7 @@ func (g *Graph) createSink(fadeIn, frameSize, sampleRate int) error {
 
 func (g *Graph) sourceIOBuilder() unit.IOBuilder {
 	return func(io *unit.IO, _ unit.Config) (*unit.Unit, error) {
-		io.NewOutWithFrame("output", g.in)
+		io.NewOutWithFrame("output", g.in, unit.WithDoc("audio from the input device"))
 		return unit.NewUnit(io, nil), nil
 	}
 }
@@ -95,24 +96,21 @@ func (g *Graph) Close() error {
 	return nil
 }
 
-// Patch patches a value into an input.
+// Patch patches a value into an input. Constant values are ramped to over the Graph's default smoothing time.
 func (g *Graph) Patch(v any, in *unit.In) error {
+	return g.PatchSmoothed(v, in, g.smoothing)
+}
+
+// PatchSmoothed patches a value into an input. Constant values are ramped to, from the input's current value, over a
+// number of samples; zero samples sets the value immediately, as does patching an input that takes discrete values, such as a gate.
+func (g *Graph) PatchSmoothed(v any, in *unit.In, samples int) error {
 	switch v := v.(type) {
 	case float64:
-		if err := g.Unpatch(in); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unpatch %q", in))
-		}
-		in.Fill(dsp.Float64(v))
+		return g.patchConstant(dsp.Float64(v), in, samples)
 	case int:
-		if err := g.Unpatch(in); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unpatch %q", in))
-		}
-		in.Fill(dsp.Float64(v))
+		return g.patchConstant(dsp.Float64(v), in, samples)
 	case dsp.Valuer:
-		if err := g.Unpatch(in); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unpatch %q", in))
-		}
-		in.Fill(v)
+		return g.patchConstant(v, in, samples)
 	case unit.Output:
 		if err := unit.Patch(g.graph, v, in); err != nil {
 			return errors.Wrap(err, fmt.Sprintf("patch %q into %q", v.Out(), in))
@@ -129,6 +127,18 @@ func (g *Graph) Patch(v any, in *unit.In) error {
 	return nil
 }
 
+func (g *Graph) patchConstant(v dsp.Valuer, in *unit.In, samples int) error {
+	from := in.Current()
+	if err := g.Unpatch(in); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("unpatch %q", in))
+	}
+	in.Ramp(from, v, samples)
+	return nil
+}
+
+// Smoothing returns the default number of samples constant values are ramped over when patched.
+func (g *Graph) Smoothing() int { return g.smoothing }
+
 // Unpatch disconnects any sources from an input.
 func (g *Graph) Unpatch(in *unit.In) error {
 	return unit.Unpatch(g.graph, in)
@@ -170,6 +180,8 @@ func collectProcessor(processors *[]unit.FrameProcessor, nodes []*graph.Node, si
 		if isp, ok := p.(unit.CondProcessor); ok {
 			if isp.IsProcessable() {
 				*processors = append(*processors, p)
+			} else if u, ok := p.(*unit.Unit); ok {
+				*processors = append(*processors, rampProcessor{u})
 			}
 		} else {
 			*processors = append(*processors, p)
@@ -183,6 +195,9 @@ func collectGroup(processors *[]unit.FrameProcessor, nodes []*graph.Node, single
 		if in, ok := w.Value.(*unit.In); ok && !singleSampleDisabled {
 			in.SetMode(unit.Sample)
 		}
+		if u, ok := w.Value.(*unit.Unit); ok {
+			g.units = append(g.units, u)
+		}
 		if p, ok := w.Value.(unit.SampleProcessor); ok {
 			if isp, ok := p.(unit.CondProcessor); ok {
 				if isp.IsProcessable() {
@@ -197,10 +212,14 @@ func collectGroup(processors *[]unit.FrameProcessor, nodes []*graph.Node, single
 }
 
 type group struct {
+	units      []*unit.Unit
 	processors []unit.SampleProcessor
 }
 
 func (g group) ProcessFrame(n int) {
+	for _, u := range g.units {
+		u.AdvanceRamps(n)
+	}
 	for i := 0; i < n; i++ {
 		for _, p := range g.processors {
 			p.ProcessSample(i)
@@ -218,3 +237,11 @@ func (g group) Close() error {
 	}
 	return nil
 }
+
+// rampProcessor advances the input ramps of a Unit that isn't processed itself, such as one whose outputs do the
+// processing.
+type rampProcessor struct {
+	unit *unit.Unit
+}
+
+func (p rampProcessor) ProcessFrame(n int) { p.unit.AdvanceRamps(n) }
@@ -6,8 +6,11 @@ require (
 	github.com/brettbuddin/musictheory v0.0.14
 	github.com/c-bata/go-prompt v0.2.2
 	github.com/fatih/color v1.6.0
-	github.com/go-audio/wav v0.0.0-20180112234942-5a351b022b0c
+	github.com/go-audio/aiff v1.1.0
+	github.com/go-audio/audio v1.0.0
+	github.com/go-audio/wav v1.0.0
 	github.com/gordonklaus/portaudio v0.0.0-20170726193601-9d16a7dfd668
+	github.com/mewkiz/flac v1.0.13
 	github.com/mitchellh/mapstructure v1.0.0
 	github.com/rakyll/portmidi v0.0.0-20170716032345-1246dd47c560
 	github.com/stretchr/testify v1.2.1
@@ -16,12 +19,14 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/go-audio/audio v0.0.0-20180121091956-7baf9c12ef58 // indirect
-	github.com/mattetti/audio v0.0.0-20171224025330-22c1d7c78180 // indirect
+	github.com/go-audio/riff v1.0.0 // indirect
+	github.com/icza/bitio v1.1.0 // indirect
 	github.com/mattn/go-colorable v0.0.9 // indirect
 	github.com/mattn/go-isatty v0.0.3 // indirect
 	github.com/mattn/go-runewidth v0.0.3 // indirect
 	github.com/mattn/go-tty v0.0.0-20180219170247-931426f7535a // indirect
+	github.com/mewkiz/pkg v0.0.0-20250417130911-3f050ff8c56d // indirect
+	github.com/mewpkg/term v0.0.0-20241026122259-37a80af23985 // indirect
 	github.com/pkg/term v0.0.0-20160705081919-b1f72af2d630 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sys v0.1.0 // indirect
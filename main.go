@@ -99,6 +99,8 @@ func run(cfg Config) error {
 
 	opts := []engine.Option{
 		engine.WithFadeIn(cfg.FadeIn),
+		engine.WithSmoothing(cfg.Smoothing),
+		engine.WithControlPeriod(cfg.ControlPeriod),
 		engine.WithGain(dbToFloat(cfg.Gain)),
 	}
 	if cfg.SingleSampleDisabled {
@@ -115,6 +117,10 @@ func run(cfg Config) error {
 		return errors.Wrap(err, "start lisp runtime failed")
 	}
 
+	if cfg.UnitReference {
+		return run.WriteUnitReference(os.Stdout)
+	}
+
 	// Start the HTTP server
 	go func() {
 		mux := http.NewServeMux()
@@ -130,6 +136,7 @@ func run(cfg Config) error {
 		mux.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
 
 		runtime.AddHandler(mux, run)
+		runtime.AddUnitHandler(mux, run)
 		if err := http.ListenAndServe(cfg.HTTPAddr, mux); err != nil {
 			logger.Fatal(err)
 		}
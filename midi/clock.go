@@ -19,7 +19,7 @@ func newClock(creator streamCreator, receiver eventReceiver) func(*unit.IO, unit
 			return nil, err
 		}
 
-		stream, err := creator.NewStream(portmidi.DeviceID(config.Device), int64(c.FrameSize))
+		stream, err := openStream(creator, c, config.Device)
 		if err != nil {
 			return nil, err
 		}
@@ -37,11 +37,11 @@ func newClock(creator streamCreator, receiver eventReceiver) func(*unit.IO, unit
 			eventChan: stream.Channel(time.Duration(config.Rate) * time.Millisecond),
 			receiver:  receiver,
 			frameRate: config.FrameRate,
-			out:       io.NewOut("out"),
-			reset:     io.NewOut("reset"),
-			start:     io.NewOut("start"),
-			stop:      io.NewOut("stop"),
-			spp:       io.NewOut("spp"),
+			out:       io.NewOut("out", unit.WithMeasure(unit.MeasureGate), unit.WithDoc("high on each beat")),
+			reset:     io.NewOut("reset", unit.WithMeasure(unit.MeasureGate), unit.WithDoc("high when the clock is reset")),
+			start:     io.NewOut("start", unit.WithMeasure(unit.MeasureGate), unit.WithDoc("high when the clock starts")),
+			stop:      io.NewOut("stop", unit.WithMeasure(unit.MeasureGate), unit.WithDoc("high when the clock stops")),
+			spp:       io.NewOut("spp", unit.WithDoc("song position pointer")),
 		}), nil
 	}
 }
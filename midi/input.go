@@ -32,7 +32,7 @@ func newInput(creator streamCreator, receiver eventReceiver) func(*unit.IO, unit
 			return nil, err
 		}
 
-		stream, err := creator.NewStream(portmidi.DeviceID(config.Device), int64(c.FrameSize))
+		stream, err := openStream(creator, c, config.Device)
 		if err != nil {
 			return nil, err
 		}
@@ -58,6 +58,7 @@ func newInput(creator streamCreator, receiver eventReceiver) func(*unit.IO, unit
 			io.ExposeOutputProcessor(ctrl.newPitch(ch))
 			io.ExposeOutputProcessor(ctrl.newPitchRaw(ch))
 			io.ExposeOutputProcessor(ctrl.newGate(ch))
+			io.ExposeOutputProcessor(ctrl.newVelocity(ch))
 			io.ExposeOutputProcessor(ctrl.newBend(ch))
 			for i := 1; i < 128; i++ {
 				io.ExposeOutputProcessor(ctrl.newCC(ch, i))
@@ -81,7 +82,7 @@ func (in *input) newPitch(ch int) *pitch {
 	return &pitch{
 		input: in,
 		ch:    ch,
-		out:   unit.NewOut(fmt.Sprintf("%d/pitch", ch), make([]float64, in.frameSize)),
+		out:   unit.NewOut(fmt.Sprintf("%d/pitch", ch), make([]float64, in.frameSize), unit.WithMeasure(unit.MeasureHz), unit.WithDoc("frequency of the last note played")),
 	}
 }
 
@@ -89,7 +90,7 @@ func (in *input) newPitchRaw(ch int) *pitchRaw {
 	return &pitchRaw{
 		input: in,
 		ch:    ch,
-		out:   unit.NewOut(fmt.Sprintf("%d/pitchraw", ch), make([]float64, in.frameSize)),
+		out:   unit.NewOut(fmt.Sprintf("%d/pitchraw", ch), make([]float64, in.frameSize), unit.WithDoc("MIDI note number of the last note played")),
 	}
 }
 
@@ -98,7 +99,15 @@ func (in *input) newGate(ch int) *gate {
 		input:   in,
 		stateFn: gateUp,
 		state:   &gateState{which: -1, chOffset: int64(ch) - 1},
-		out:     unit.NewOut(fmt.Sprintf("%d/gate", ch), make([]float64, in.frameSize)),
+		out:     unit.NewOut(fmt.Sprintf("%d/gate", ch), make([]float64, in.frameSize), unit.WithMeasure(unit.MeasureGate), unit.WithDoc("high while a note is held")),
+	}
+}
+
+func (in *input) newVelocity(ch int) *velocity {
+	return &velocity{
+		input: in,
+		ch:    int64(ch),
+		out:   unit.NewOut(fmt.Sprintf("%d/velocity", ch), make([]float64, in.frameSize), unit.WithRange(0, 1), unit.WithDoc("velocity of the last note played")),
 	}
 }
 
@@ -107,7 +116,7 @@ func (in *input) newCC(ch, num int) *cc {
 		input: in,
 		ch:    int64(ch),
 		num:   int64(num),
-		out:   unit.NewOut(fmt.Sprintf("%d/cc/%d", ch, num), make([]float64, in.frameSize)),
+		out:   unit.NewOut(fmt.Sprintf("%d/cc/%d", ch, num), make([]float64, in.frameSize), unit.WithRange(0, 1), unit.WithDoc("value of the controller")),
 	}
 }
 
@@ -115,7 +124,7 @@ func (in *input) newBend(ch int) *bend {
 	return &bend{
 		input: in,
 		ch:    int64(ch),
-		out:   unit.NewOut(fmt.Sprintf("%d/bend", ch), make([]float64, in.frameSize)),
+		out:   unit.NewOut(fmt.Sprintf("%d/bend", ch), make([]float64, in.frameSize), unit.WithRange(0, 1), unit.WithDoc("pitch bend")),
 	}
 }
 
@@ -191,6 +200,29 @@ func (o *pitchRaw) ProcessSample(i int) {
 	o.out.Write(i, o.note)
 }
 
+type velocity struct {
+	input *input
+	ch    int64
+	value float64
+	out   *unit.Out
+}
+
+func (o *velocity) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
+func (o *velocity) Out() *unit.Out      { return o.out }
+
+func (o *velocity) ProcessFrame(n int) {
+	for i := 0; i < n; i++ {
+		o.ProcessSample(i)
+	}
+}
+
+func (o *velocity) ProcessSample(i int) {
+	if e := o.input.events[i]; e.Status == statusNoteOn+o.ch-1 && e.Data2 > 0 {
+		o.value = float64(e.Data2) / 127
+	}
+	o.out.Write(i, o.value)
+}
+
 type gate struct {
 	input   *input
 	state   *gateState
@@ -21,6 +21,7 @@ var _ = []midiOutput{
 	&pitch{},
 	&pitchRaw{},
 	&gate{},
+	&velocity{},
 	&bend{},
 	&cc{},
 }
@@ -79,6 +80,33 @@ func TestInput_PitchRaw(t *testing.T) {
 	u.Close()
 }
 
+func TestInput_Velocity(t *testing.T) {
+	ch := make(chan portmidi.Event)
+	creator := streamCreatorFunc(func(deviceID portmidi.DeviceID, frameSize int64) (eventStream, error) {
+		return streamMock{
+			events: ch,
+		}, nil
+	})
+
+	go func() {
+		ch <- portmidi.Event{Status: 144, Data1: 60, Data2: 64}
+	}()
+
+	u, err := newInput(creator, blockingReceiver)(unit.NewIO("midi-input", frameSize), newUnitConfig(nil))
+	require.NoError(t, err)
+	require.NotNil(t, u)
+
+	u.ProcessFrame(1)
+
+	velocityOut := u.Out["1/velocity"].(*velocity)
+	velocityOut.ProcessFrame(2)
+
+	require.Equal(t, 64.0/127, velocityOut.out.Read(0))
+	require.Equal(t, 64.0/127, velocityOut.out.Read(1))
+
+	u.Close()
+}
+
 func TestInput_Gate_NoteOff(t *testing.T) {
 	ch := make(chan portmidi.Event)
 	creator := streamCreatorFunc(func(deviceID portmidi.DeviceID, frameSize int64) (eventStream, error) {
@@ -255,3 +283,17 @@ type streamMock struct {
 
 func (s streamMock) Channel(time.Duration) <-chan portmidi.Event { return s.events }
 func (s streamMock) Close() error                                { return s.err }
+
+func TestInput_Describing(t *testing.T) {
+	creator := streamCreatorFunc(func(deviceID portmidi.DeviceID, frameSize int64) (eventStream, error) {
+		t.Fatal("stream opened while describing")
+		return nil, nil
+	})
+
+	c := newUnitConfig(nil)
+	c.Describing = true
+	u, err := newInput(creator, blockingReceiver)(unit.NewIO("midi-input", frameSize), c)
+	require.NoError(t, err)
+	require.Contains(t, u.Out, "1/pitch")
+	require.NoError(t, u.Close())
+}
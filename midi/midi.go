@@ -93,6 +93,21 @@ type eventStream interface {
 	io.Closer
 }
 
+// openStream opens a stream of events from a device. Units that are only being described get a stream that never emits
+// events, so that describing them leaves the device alone.
+func openStream(creator streamCreator, c unit.Config, device int) (eventStream, error) {
+	if c.Describing {
+		return nopStream{}, nil
+	}
+	return creator.NewStream(portmidi.DeviceID(device), int64(c.FrameSize))
+}
+
+// nopStream is an eventStream that never emits events.
+type nopStream struct{}
+
+func (nopStream) Channel(time.Duration) <-chan portmidi.Event { return nil }
+func (nopStream) Close() error                                { return nil }
+
 type stream struct {
 	*portmidi.Stream
 	stop chan struct{}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"sort"
+
+	"github.com/brettbuddin/shaden/engine"
+	"github.com/brettbuddin/shaden/errors"
+	"github.com/brettbuddin/shaden/lisp"
+	"github.com/brettbuddin/shaden/unit"
+)
+
+const (
+	nameBufferLoad   = "buffer-load"
+	nameBufferSave   = "buffer-save"
+	nameBufferUnload = "buffer-unload"
+	nameBuffers      = "buffers"
+)
+
+func (r *Runtime) loadBuffers(env *lisp.Environment) {
+	env.DefineSymbol(nameBufferLoad, r.bufferLoad)
+	env.DefineSymbol(nameBufferSave, r.bufferSave)
+	env.DefineSymbol(nameBufferUnload, r.bufferUnload)
+	env.DefineSymbol(nameBuffers, r.bufferNames)
+}
+
+// bufferLoad decodes an audio file into a named buffer that units can share with (table :buffer name). Loading the
+// same file under the same name again is free.
+func (r *Runtime) bufferLoad(args lisp.List) (any, error) {
+	if err := lisp.CheckArityEqual(args, 2); err != nil {
+		return nil, err
+	}
+	name, ok := bufferName(args[0])
+	if !ok {
+		return nil, lisp.ArgExpectError(lisp.AcceptTypes(lisp.TypeString, lisp.TypeKeyword), 1)
+	}
+	path, ok := args[1].(string)
+	if !ok {
+		return nil, lisp.ArgExpectError(lisp.TypeString, 2)
+	}
+	if _, err := r.buffers.Load(name, path); err != nil {
+		return nil, errors.Wrapf(err, "loading buffer %q", name)
+	}
+	return args[0], nil
+}
+
+// bufferSave writes a named buffer, or the audio held by a unit (e.g. the recording of unit/looper), to a WAV file.
+func (r *Runtime) bufferSave(args lisp.List) (any, error) {
+	if err := lisp.CheckArityEqual(args, 2); err != nil {
+		return nil, err
+	}
+	path, ok := args[1].(string)
+	if !ok {
+		return nil, lisp.ArgExpectError(lisp.TypeString, 2)
+	}
+
+	var buf *unit.Buffer
+	switch v := args[0].(type) {
+	case *lazyUnit:
+		var err error
+		if buf, err = r.captureBuffer(v); err != nil {
+			return nil, err
+		}
+	default:
+		name, ok := bufferName(v)
+		if !ok {
+			return nil, lisp.ArgExpectError(lisp.AcceptTypes(typeUnit, lisp.TypeString, lisp.TypeKeyword), 1)
+		}
+		if buf, ok = r.buffers.Get(name); !ok {
+			return nil, errors.Errorf("unknown buffer %q", name)
+		}
+	}
+	return nil, buf.SaveWAV(path)
+}
+
+// captureBuffer copies the audio held by a unit. Mounted units are copied between frames by the engine so the copy
+// doesn't race with processing.
+func (r *Runtime) captureBuffer(u *lazyUnit) (*unit.Buffer, error) {
+	src, ok := u.created.SampleProcessor.(unit.BufferSource)
+	if !ok {
+		return nil, errors.Errorf("unit %s does not hold a buffer", u.id)
+	}
+	if !u.mount {
+		return src.Buffer(), nil
+	}
+
+	var buf *unit.Buffer
+	msg := engine.NewMessage(func(*engine.Graph) error {
+		buf = src.Buffer()
+		return nil
+	})
+	if err := r.engine.SendMessage(msg); err != nil {
+		return nil, err
+	}
+	reply := <-msg.Reply
+	if reply.Error != nil {
+		return nil, reply.Error
+	}
+	return buf, nil
+}
+
+func (r *Runtime) bufferUnload(args lisp.List) (any, error) {
+	if err := lisp.CheckArityEqual(args, 1); err != nil {
+		return nil, err
+	}
+	name, ok := bufferName(args[0])
+	if !ok {
+		return nil, lisp.ArgExpectError(lisp.AcceptTypes(lisp.TypeString, lisp.TypeKeyword), 1)
+	}
+	r.buffers.Remove(name)
+	return nil, nil
+}
+
+func (r *Runtime) bufferNames(args lisp.List) (any, error) {
+	if err := lisp.CheckArityEqual(args, 0); err != nil {
+		return nil, err
+	}
+	names := r.buffers.Names()
+	sort.Strings(names)
+	l := lisp.List{}
+	for _, name := range names {
+		l = append(l, name)
+	}
+	return l, nil
+}
+
+func bufferName(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case lisp.Keyword:
+		return string(v), true
+	}
+	return "", false
+}
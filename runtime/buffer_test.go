@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/engine"
+	"github.com/brettbuddin/shaden/lisp"
+	"github.com/brettbuddin/shaden/randtest"
+	"github.com/brettbuddin/shaden/unit"
+)
+
+func TestBuffers(t *testing.T) {
+	var (
+		be       = newBackend(0)
+		messages = messageChannel{make(chan *engine.Message)}
+		eng, err = engine.New(be, frameSize, engine.WithMessageChannel(messages))
+		logger   = log.New(os.Stdout, "", -1)
+		dir      = t.TempDir()
+		in       = filepath.Join(dir, "in.wav")
+		out      = filepath.Join(dir, "out.wav")
+	)
+	require.NoError(t, err)
+
+	src := &unit.Buffer{Data: []float64{0.5, -0.5, 0.25}, Channels: 1, SampleRate: 44100}
+	require.NoError(t, src.SaveWAV(in))
+
+	run, err := New(eng, logger, randtest.Static())
+	require.NoError(t, err)
+	v, err := run.Eval([]byte(`
+		(buffer-load :hit "` + in + `")
+		(define a (unit/sample (table :buffer :hit)))
+		(define b (unit/granular (table :buffer "hit")))
+		(buffers)
+	`))
+	require.NoError(t, err)
+	require.Equal(t, lisp.List{"hit"}, v)
+
+	_, err = run.Eval([]byte(`(buffer-save :hit "` + out + `")`))
+	require.NoError(t, err)
+	saved, err := unit.LoadBuffer(out)
+	require.NoError(t, err)
+	require.Equal(t, 3, saved.Frames())
+	require.InDelta(t, -0.5, saved.Data[1], 1e-4)
+
+	_, err = run.Eval([]byte(`
+		(define l (unit/looper (table :length (ms 10))))
+		(buffer-save l "` + out + `")
+	`))
+	require.NoError(t, err)
+	saved, err = unit.LoadBuffer(out)
+	require.NoError(t, err)
+	require.Equal(t, 0, saved.Frames())
+
+	_, err = run.Eval([]byte(`(buffer-save (unit/noop) "` + out + `")`))
+	require.Error(t, err)
+
+	_, err = run.Eval([]byte(`(buffer-unload :hit) (unit/sample (table :buffer :hit))`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown buffer "hit"`)
+}
@@ -0,0 +1,154 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/brettbuddin/shaden/errors"
+	"github.com/brettbuddin/shaden/lisp"
+	"github.com/brettbuddin/shaden/unit"
+)
+
+// UnitDescription describes a type of unit. Error is populated when a unit of the type cannot be built without
+// additional configuration (e.g. a file path).
+type UnitDescription struct {
+	unit.Description
+	Error string `json:"error,omitempty"`
+}
+
+// describe builds a throwaway unit with the provided configuration and describes it. The unit is built without opening
+// devices or starting goroutines, and closed straight away.
+func (b *unitBuilder) describe(values map[string]any) (unit.Description, error) {
+	c := b.config(values)
+	c.Describing = true
+	u, err := b.build(c)
+	if err != nil {
+		return unit.Description{Type: b.typ}, err
+	}
+	defer u.Close()
+	return u.Describe(), nil
+}
+
+// DescribeUnits returns descriptions of all unit types available in the runtime, sorted by type.
+func (r *Runtime) DescribeUnits() []UnitDescription {
+	var names []string
+	for name := range r.builders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descs := make([]UnitDescription, 0, len(names))
+	for _, name := range names {
+		desc, _ := r.DescribeUnit(name)
+		descs = append(descs, desc)
+	}
+	return descs
+}
+
+// DescribeUnit returns a description of a single unit type. The boolean result reports whether the type exists.
+func (r *Runtime) DescribeUnit(typ string) (UnitDescription, bool) {
+	b, ok := r.builders[typ]
+	if !ok {
+		return UnitDescription{}, false
+	}
+	d, err := b.describe(nil)
+	desc := UnitDescription{Description: d}
+	if err != nil {
+		desc.Error = err.Error()
+	}
+	return desc, true
+}
+
+// WriteUnitReference writes a Markdown reference page for all unit types available in the runtime.
+func (r *Runtime) WriteUnitReference(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Unit Reference\n")
+	for _, desc := range r.DescribeUnits() {
+		fmt.Fprintf(&b, "\n## unit/%s\n", desc.Type)
+		if desc.Error != "" {
+			fmt.Fprintf(&b, "\nRequires configuration: %s\n", desc.Error)
+			continue
+		}
+		writePortTable(&b, "Inputs", desc.Inputs)
+		writePortTable(&b, "Outputs", desc.Outputs)
+		writePortTable(&b, "Properties", desc.Props)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePortTable(b *strings.Builder, title string, ports []unit.PortDescription) {
+	if len(ports) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	b.WriteString("| Name | Default | Measure | Range | Description |\n")
+	b.WriteString("|------|---------|---------|-------|-------------|\n")
+	for _, p := range ports {
+		var rng string
+		if len(p.Range) == 2 {
+			rng = fmt.Sprintf("%g – %g", p.Range[0], p.Range[1])
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n", p.Name, p.Default, p.Measure, rng, p.Doc)
+	}
+}
+
+func unitDescribeFn(args lisp.List) (any, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, errors.Errorf("expects 1 or 2 arguments")
+	}
+
+	switch v := args[0].(type) {
+	case *unitBuilder:
+		config, err := unitConfig(args[1:], 1)
+		if err != nil {
+			return nil, lisp.ArgExpectError(lisp.TypeTable, 2)
+		}
+		d, err := v.describe(config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing %s", v.Name())
+		}
+		return descriptionTable(d), nil
+	case *lazyUnit:
+		if len(args) > 1 {
+			return nil, errors.Errorf("configuration can only be provided when describing a unit type")
+		}
+		return descriptionTable(v.created.Describe()), nil
+	default:
+		return nil, lisp.ArgExpectError(lisp.AcceptTypes(typeUnit, typeUnitBuilder), 1)
+	}
+}
+
+func descriptionTable(d unit.Description) lisp.Table {
+	return lisp.Table{
+		lisp.Keyword("type"):    d.Type,
+		lisp.Keyword("inputs"):  portList(d.Inputs),
+		lisp.Keyword("outputs"): portList(d.Outputs),
+		lisp.Keyword("props"):   portList(d.Props),
+	}
+}
+
+func portList(ports []unit.PortDescription) lisp.List {
+	l := lisp.List{}
+	for _, p := range ports {
+		t := lisp.Table{
+			lisp.Keyword("name"): p.Name,
+		}
+		if p.Default != "" {
+			t[lisp.Keyword("default")] = p.Default
+		}
+		if p.Measure != unit.MeasureNone {
+			t[lisp.Keyword("measure")] = string(p.Measure)
+		}
+		if len(p.Range) == 2 {
+			t[lisp.Keyword("range")] = lisp.List{p.Range[0], p.Range[1]}
+		}
+		if p.Doc != "" {
+			t[lisp.Keyword("doc")] = p.Doc
+		}
+		l = append(l, t)
+	}
+	return l
+}
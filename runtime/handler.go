@@ -1,9 +1,11 @@
 package runtime
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 // Evaler evaluates script content sent via HTTP.
@@ -11,6 +13,12 @@ type Evaler interface {
 	Eval([]byte) (any, error)
 }
 
+// UnitDescriber describes the types of units available.
+type UnitDescriber interface {
+	DescribeUnits() []UnitDescription
+	DescribeUnit(string) (UnitDescription, bool)
+}
+
 // ServeMux is a mux abstraction.
 type ServeMux interface {
 	Handle(string, http.Handler)
@@ -35,3 +43,32 @@ func AddHandler(mux ServeMux, evaler Evaler) {
 		fmt.Fprintf(w, "OK")
 	}))
 }
+
+// AddUnitHandler registers the unit description handlers with a ServeMux. GET /units lists descriptions of all unit
+// types; GET /units/<type> describes a single type.
+func AddUnitHandler(mux ServeMux, describer UnitDescriber) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		var v any = describer.DescribeUnits()
+		if typ := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/units"), "/"); typ != "" {
+			desc, ok := describer.DescribeUnit(strings.TrimPrefix(typ, "unit/"))
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "unknown unit %q", typ)
+				return
+			}
+			v = desc
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	mux.Handle("/units", h)
+	mux.Handle("/units/", h)
+}
@@ -2,12 +2,14 @@ package runtime
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/brettbuddin/shaden/errors"
+	"github.com/brettbuddin/shaden/unit"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,3 +106,54 @@ func (e *evaler) Eval(b []byte) (interface{}, error) {
 	e.content = b
 	return e.val, e.err
 }
+
+func TestUnitHandler(t *testing.T) {
+	var (
+		mux = http.NewServeMux()
+		d   = describer{
+			"noop": UnitDescription{Description: unit.Description{Type: "noop"}},
+		}
+	)
+
+	AddUnitHandler(mux, d)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	client := s.Client()
+
+	resp, err := client.Get(s.URL + "/units")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var all []UnitDescription
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&all))
+	require.Equal(t, []UnitDescription{d["noop"]}, all)
+
+	resp, err = client.Get(s.URL + "/units/noop")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var one UnitDescription
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&one))
+	require.Equal(t, d["noop"], one)
+
+	resp, err = client.Get(s.URL + "/units/missing")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+type describer map[string]UnitDescription
+
+func (d describer) DescribeUnits() []UnitDescription {
+	var descs []UnitDescription
+	for _, desc := range d {
+		descs = append(descs, desc)
+	}
+	return descs
+}
+
+func (d describer) DescribeUnit(typ string) (UnitDescription, bool) {
+	desc, ok := d[typ]
+	return desc, ok
+}
@@ -10,6 +10,7 @@ import (
 
 	prompt "github.com/c-bata/go-prompt"
 
+	"github.com/brettbuddin/shaden/dsp"
 	"github.com/brettbuddin/shaden/engine"
 	"github.com/brettbuddin/shaden/errors"
 	"github.com/brettbuddin/shaden/lisp"
@@ -23,6 +24,7 @@ type Engine interface {
 	UnitBuilders() map[string]unit.Builder
 	FrameSize() int
 	SampleRate() int
+	ControlPeriod() int
 }
 
 // Runtime represents the runtime execution environment
@@ -31,6 +33,8 @@ type Runtime struct {
 	engine     Engine
 	rand       *rand.Rand
 	logger     *log.Logger
+	builders   map[string]*unitBuilder
+	buffers    *unit.Buffers
 }
 
 // New returns a new Runtime
@@ -38,11 +42,12 @@ func New(e Engine, logger *log.Logger, rng *rand.Rand) (*Runtime, error) {
 	base := lisp.NewEnvironment()
 	builtin.Load(base)
 	r := &Runtime{
-		base:   base,
-		user:   base.Branch(),
-		engine: e,
-		rand:   rng,
-		logger: logger,
+		base:    base,
+		user:    base.Branch(),
+		engine:  e,
+		rand:    rng,
+		logger:  logger,
+		buffers: unit.NewBuffers(),
 	}
 	if err := r.loadShaden(); err != nil {
 		return nil, err
@@ -128,21 +133,28 @@ func (r *Runtime) loadShaden() error {
 	// Engine
 	env.DefineSymbol("emit", emitFn(engine, logger))
 	env.DefineSymbol("clear", r.engineClear)
+	env.DefineSymbol("smoothing", r.engineSmoothing)
 
 	// Units
-	if err := createBuilders(env, engine, logger, r.rand); err != nil {
+	builders, err := createBuilders(env, engine, logger, r.rand, r.buffers)
+	if err != nil {
 		return err
 	}
+	r.builders = builders
 	env.DefineSymbol(nameUnitID, unitIDFn)
 	env.DefineSymbol(nameUnitType, unitTypeFn)
 	env.DefineSymbol(nameUnitInputs, unitInputsFn)
 	env.DefineSymbol(nameUnitOutputs, unitOutputsFn)
+	env.DefineSymbol(nameUnitDescribe, unitDescribeFn)
 	env.DefineSymbol(nameUnitUnmount, unitUnmountFn(engine, logger))
 	env.DefineSymbol(nameUnitRemove, unitRemoveFn(engine, logger))
 	env.DefineSymbol(nameUnitPatch, patchFn(engine, logger, true))
 	env.DefineSymbol(nameUnitPatchOnly, patchFn(engine, logger, false))
 	env.DefineSymbol(nameUnitOutput, outFn(engine))
 
+	// Buffers
+	r.loadBuffers(env)
+
 	return nil
 }
 
@@ -159,6 +171,10 @@ func (r *Runtime) loadConstants(env *lisp.Environment, sampleRate, frameSize int
 	env.DefineSymbol("samplerate", sampleRate)
 	env.DefineSymbol("framesize", frameSize)
 
+	// Processing rates
+	env.DefineSymbol("rate/audio", int(unit.RateAudio))
+	env.DefineSymbol("rate/control", int(unit.RateControl))
+
 	// Basic Modes
 	env.DefineSymbol("mode/on", 1)
 	env.DefineSymbol("mode/off", 0)
@@ -194,6 +210,50 @@ func (r *Runtime) loadConstants(env *lisp.Environment, sampleRate, frameSize int
 	// Mix Modes
 	env.DefineSymbol("mode/sum", 0)
 	env.DefineSymbol("mode/average", 1)
+
+	// Follower Modes
+	env.DefineSymbol("mode/peak", 0)
+	env.DefineSymbol("mode/rms", 1)
+
+	// EQ Band Types
+	env.DefineSymbol("eq/peak", 0)
+	env.DefineSymbol("eq/low-shelf", 1)
+	env.DefineSymbol("eq/high-shelf", 2)
+	env.DefineSymbol("eq/notch", 3)
+	env.DefineSymbol("eq/low-cut", 4)
+	env.DefineSymbol("eq/high-cut", 5)
+
+	// Window Shapes
+	env.DefineSymbol("window/hann", 0)
+	env.DefineSymbol("window/triangle", 1)
+	env.DefineSymbol("window/tukey", 2)
+	env.DefineSymbol("window/gaussian", 3)
+}
+
+func (r *Runtime) engineSmoothing(args lisp.List) (any, error) {
+	if err := lisp.CheckArityEqual(args, 1); err != nil {
+		return nil, err
+	}
+	var samples int
+	switch v := args[0].(type) {
+	case dsp.Valuer:
+		samples = int(v.Float64())
+	case int:
+		samples = v
+	case float64:
+		samples = int(v)
+	default:
+		return nil, lisp.ArgExpectError(lisp.AcceptTypes(lisp.TypeInt, lisp.TypeFloat, "duration"), 1)
+	}
+	msg := engine.NewMessage(engine.SetSmoothing(samples))
+	if err := r.engine.SendMessage(msg); err != nil {
+		return nil, err
+	}
+	reply := <-msg.Reply
+	if reply.Error != nil {
+		return nil, reply.Error
+	}
+	return nil, nil
 }
 
 func (r *Runtime) engineClear(*lisp.Environment, lisp.List) (any, error) {
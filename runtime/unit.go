@@ -10,6 +10,7 @@ import (
 
 	"github.com/fatih/color"
 
+	"github.com/brettbuddin/shaden/dsp"
 	"github.com/brettbuddin/shaden/engine"
 	"github.com/brettbuddin/shaden/errors"
 	"github.com/brettbuddin/shaden/lisp"
@@ -22,6 +23,7 @@ const (
 	nameUnitRemove    = "unit-remove"
 	nameUnitInputs    = "unit-inputs"
 	nameUnitOutputs   = "unit-outputs"
+	nameUnitDescribe  = "unit-describe"
 	nameUnitType      = "unit-type"
 	nameUnitID        = "unit-id"
 	nameUnitPatch     = "->"
@@ -29,8 +31,9 @@ const (
 	nameUnitOutput    = "<-"
 	nameEmit          = "emit"
 
-	typeUnit      = "unit"
-	typeOutputRef = "output reference"
+	typeUnit        = "unit"
+	typeOutputRef   = "output reference"
+	typeUnitBuilder = "unit builder"
 )
 
 var bold = color.New(color.Bold).SprintFunc()
@@ -94,15 +97,25 @@ func (u *lazyUnit) Replace(v any) error {
 	return reply.Error
 }
 
-func createBuilders(env *lisp.Environment, e Engine, logger *log.Logger, rng *rand.Rand) error {
+func createBuilders(env *lisp.Environment, e Engine, logger *log.Logger, rng *rand.Rand, buffers *unit.Buffers) (map[string]*unitBuilder, error) {
 	builders, err := unitBuilders(e)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	created := map[string]*unitBuilder{}
 	for name, builder := range builders {
-		defineBuilders(env, builder, e, logger, rng, "unit/"+name)
+		b := &unitBuilder{
+			typ:     name,
+			build:   builder,
+			engine:  e,
+			logger:  logger,
+			rand:    rng,
+			buffers: buffers,
+		}
+		env.DefineSymbol(b.Name(), b)
+		created[name] = b
 	}
-	return nil
+	return created, nil
 }
 
 func unitBuilders(e Engine) (map[string]unit.Builder, error) {
@@ -110,6 +123,7 @@ func unitBuilders(e Engine) (map[string]unit.Builder, error) {
 		unit.Builders(),
 		e.UnitBuilders(),
 		midi.UnitBuilders(),
+		unit.RegisteredBuilders(),
 	}
 	merged := map[string]unit.Builder{}
 	for _, g := range groups {
@@ -123,60 +137,91 @@ func unitBuilders(e Engine) (map[string]unit.Builder, error) {
 	return merged, nil
 }
 
-func defineBuilders(env *lisp.Environment, builder unit.Builder, e Engine, logger *log.Logger, rng *rand.Rand, name string) {
-	env.DefineSymbol(name, func(args lisp.List) (any, error) {
-		if len(args) > 1 {
-			return nil, errors.Errorf("expects at most 1 argument")
-		}
+// unitBuilder is the value bound to each unit/* symbol. Calling it creates a new unit of its type.
+type unitBuilder struct {
+	typ     string
+	build   unit.Builder
+	engine  Engine
+	logger  *log.Logger
+	rand    *rand.Rand
+	buffers *unit.Buffers
+}
 
-		config := map[string]any{}
-		if len(args) == 1 {
-			m, ok := args[0].(lisp.Table)
-			if !ok {
-				return nil, lisp.ArgExpectError(lisp.TypeTable, 1)
-			}
-			for k, v := range m {
-				switch k := k.(type) {
-				case string:
-					config[k] = v
-				case lisp.Keyword:
-					config[string(k)] = v
-				default:
-					config[fmt.Sprintf("%v", k)] = v
-				}
-			}
-		}
+func (b *unitBuilder) String() string { return "unit/" + b.typ }
 
-		unit, err := builder(unit.Config{
-			Values:     config,
-			Rand:       rng,
-			SampleRate: e.SampleRate(),
-			FrameSize:  e.FrameSize(),
-		})
-		if err != nil {
-			return nil, err
-		}
+// Name implements lisp.Func.
+func (b *unitBuilder) Name() string { return "unit/" + b.typ }
 
-		var inputs, outputs []string
-		for k := range unit.In {
-			inputs = append(inputs, k)
-		}
-		for k := range unit.Out {
-			outputs = append(outputs, k)
-		}
-		natsort(inputs)
-		natsort(outputs)
+// Func implements lisp.Func.
+func (b *unitBuilder) Func(args lisp.List) (any, error) {
+	if len(args) > 1 {
+		return nil, errors.Errorf("expects at most 1 argument")
+	}
 
-		return &lazyUnit{
-			logger:  logger,
-			engine:  e,
-			created: unit,
-			id:      unit.ID,
-			typ:     unit.Type,
-			inputs:  inputs,
-			outputs: outputs,
-		}, nil
-	})
+	config, err := unitConfig(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := b.build(b.config(config))
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs, outputs []string
+	for k := range unit.In {
+		inputs = append(inputs, k)
+	}
+	for k := range unit.Out {
+		outputs = append(outputs, k)
+	}
+	natsort(inputs)
+	natsort(outputs)
+
+	return &lazyUnit{
+		logger:  b.logger,
+		engine:  b.engine,
+		created: unit,
+		id:      unit.ID,
+		typ:     unit.Type,
+		inputs:  inputs,
+		outputs: outputs,
+	}, nil
+}
+
+func (b *unitBuilder) config(values map[string]any) unit.Config {
+	return unit.Config{
+		Values:        values,
+		Rand:          b.rand,
+		SampleRate:    b.engine.SampleRate(),
+		FrameSize:     b.engine.FrameSize(),
+		ControlPeriod: b.engine.ControlPeriod(),
+		Buffers:       b.buffers,
+	}
+}
+
+// unitConfig converts an optional table argument at position `pos` into the configuration values given to a
+// unit.Builder.
+func unitConfig(args lisp.List, pos int) (map[string]any, error) {
+	config := map[string]any{}
+	if len(args) < pos {
+		return config, nil
+	}
+	m, ok := args[pos-1].(lisp.Table)
+	if !ok {
+		return nil, lisp.ArgExpectError(lisp.TypeTable, pos)
+	}
+	for k, v := range m {
+		switch k := k.(type) {
+		case string:
+			config[k] = v
+		case lisp.Keyword:
+			config[string(k)] = v
+		default:
+			config[fmt.Sprintf("%v", k)] = v
+		}
+	}
+	return config, nil
 }
 
 func unitRemoveFn(e Engine, logger *log.Logger) func(*lisp.Environment, lisp.List) (any, error) {
@@ -254,6 +299,8 @@ func patchFn(e Engine, logger *log.Logger, forceReset bool) func(lisp.List) (any
 			return nil, lisp.ArgExpectError(typeUnit, 1)
 		}
 
+		args, smoothing, smoothed := patchSmoothing(args)
+
 		inputs, err := patchableInputs(args[1:])
 		if err != nil {
 			return nil, err
@@ -264,7 +311,11 @@ func patchFn(e Engine, logger *log.Logger, forceReset bool) func(lisp.List) (any
 			return nil, errors.Wrap(err, "retrieving mounted unit failed")
 		}
 
-		m := engine.NewMessage(engine.PatchInput(u, inputs, forceReset))
+		action := engine.PatchInput(u, inputs, forceReset)
+		if smoothed {
+			action = engine.PatchInputSmoothed(u, inputs, forceReset, smoothing)
+		}
+		m := engine.NewMessage(action)
 
 		if err := e.SendMessage(m); err != nil {
 			return nil, err
@@ -294,6 +345,25 @@ func patchFn(e Engine, logger *log.Logger, forceReset bool) func(lisp.List) (any
 	}
 }
 
+// patchSmoothing splits an optional trailing duration off of the arguments to a patch call. The duration overrides the
+// default smoothing time for constant values: (-> filter (table :cutoff (hz 300)) (ms 50))
+func patchSmoothing(args lisp.List) (lisp.List, int, bool) {
+	if len(args) < 3 {
+		return args, 0, false
+	}
+	if len(args) == 3 {
+		switch args[1].(type) {
+		case lisp.Keyword, string:
+			return args, 0, false
+		}
+	}
+	d, ok := args[len(args)-1].(dsp.MS)
+	if !ok {
+		return args, 0, false
+	}
+	return args[:len(args)-1], int(d.Float64()), true
+}
+
 func patchableInputs(args lisp.List) (map[string]any, error) {
 	inputs := map[string]any{}
 
@@ -10,8 +10,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/brettbuddin/shaden/dsp"
 	"github.com/brettbuddin/shaden/engine"
 	"github.com/brettbuddin/shaden/lisp"
+	"github.com/brettbuddin/shaden/midi"
 	"github.com/brettbuddin/shaden/randtest"
 	"github.com/brettbuddin/shaden/unit"
 )
@@ -92,6 +94,62 @@ func TestUnitType(t *testing.T) {
 	require.Equal(t, "noop", v)
 }
 
+// Units from outside of this repository register themselves at init, and stay registered.
+func init() {
+	err := unit.Register("registered-example", func(io *unit.IO, c unit.Config) (*unit.Unit, error) {
+		io.NewIn("in", dsp.Float64(0))
+		io.NewOut("out")
+		return unit.NewUnit(io, nil), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestUnitRegistered(t *testing.T) {
+	var (
+		be       = newBackend(0)
+		messages = messageChannel{make(chan *engine.Message)}
+		eng, err = engine.New(be, frameSize, engine.WithMessageChannel(messages))
+		logger   = log.New(os.Stdout, "", -1)
+	)
+	require.NoError(t, err)
+
+	run, err := New(eng, logger, randtest.Static())
+	require.NoError(t, err)
+	v, err := run.Eval([]byte(`
+		(define example (unit/registered-example))
+		(list (unit-type example) (unit-inputs example))
+	`))
+	require.NoError(t, err)
+	require.Equal(t, lisp.List{"registered-example", lisp.List{"in"}}, v)
+}
+
+func TestUnitRegisterReserved(t *testing.T) {
+	eng, err := engine.New(newBackend(0), frameSize)
+	require.NoError(t, err)
+
+	noop := func(io *unit.IO, c unit.Config) (*unit.Unit, error) { return unit.NewUnit(io, nil), nil }
+	for _, builders := range []map[string]unit.Builder{eng.UnitBuilders(), midi.UnitBuilders()} {
+		for name := range builders {
+			require.Error(t, unit.Register(name, noop), name)
+		}
+	}
+}
+
+func TestUnitBuildersDuplicate(t *testing.T) {
+	_, err := unitBuilders(duplicateEngine{})
+	require.EqualError(t, err, `duplicate unit named "noop"`)
+}
+
+type duplicateEngine struct{ Engine }
+
+func (duplicateEngine) UnitBuilders() map[string]unit.Builder {
+	return map[string]unit.Builder{
+		"noop": func(unit.Config) (*unit.Unit, error) { return nil, nil },
+	}
+}
+
 func TestUnitOutput(t *testing.T) {
 	var (
 		be       = newBackend(1) // execute callback once
@@ -275,3 +333,87 @@ func TestInputConversions(t *testing.T) {
 		"nested-table": map[string]interface{}{"a": "b"},
 	}, m)
 }
+
+func TestUnitDescribe(t *testing.T) {
+	var (
+		be       = newBackend(0)
+		messages = messageChannel{make(chan *engine.Message)}
+		eng, err = engine.New(be, frameSize, engine.WithMessageChannel(messages))
+		logger   = log.New(os.Stdout, "", -1)
+	)
+
+	require.NoError(t, err)
+	run, err := New(eng, logger, randtest.Static())
+	require.NoError(t, err)
+
+	v, err := run.Eval([]byte(`(unit-describe unit/noop)`))
+	require.NoError(t, err)
+	require.Equal(t, lisp.Table{
+		lisp.Keyword("type"): "noop",
+		lisp.Keyword("inputs"): lisp.List{
+			lisp.Table{
+				lisp.Keyword("name"):    "x",
+				lisp.Keyword("default"): "0.00",
+				lisp.Keyword("doc"):     "operand",
+			},
+		},
+		lisp.Keyword("outputs"): lisp.List{
+			lisp.Table{
+				lisp.Keyword("name"): "out",
+				lisp.Keyword("doc"):  "result of the operation",
+			},
+		},
+		lisp.Keyword("props"): lisp.List{},
+	}, v)
+
+	v, err = run.Eval([]byte(`
+		(define f (unit/filter))
+		(:type (unit-describe f))
+	`))
+	require.NoError(t, err)
+	require.Equal(t, "filter", v)
+
+	_, err = run.Eval([]byte(`(unit-describe 1)`))
+	require.Error(t, err)
+}
+
+func TestWriteUnitReference(t *testing.T) {
+	var (
+		be       = newBackend(0)
+		messages = messageChannel{make(chan *engine.Message)}
+		eng, err = engine.New(be, frameSize, engine.WithMessageChannel(messages))
+		logger   = log.New(os.Stdout, "", -1)
+	)
+
+	require.NoError(t, err)
+	run, err := New(eng, logger, randtest.Static())
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, run.WriteUnitReference(&b))
+	require.Contains(t, b.String(), "## unit/filter\n")
+	require.Contains(t, b.String(), "| cutoff | ")
+	require.Contains(t, b.String(), "## unit/sample\n\nRequires configuration:")
+}
+
+func TestPatchSmoothing(t *testing.T) {
+	ms := dsp.Duration(10, 44100)
+	table := lisp.Table{lisp.Keyword("cutoff"): 300}
+
+	args, samples, ok := patchSmoothing(lisp.List{nil, table, ms})
+	require.True(t, ok)
+	require.Equal(t, 441, samples)
+	require.Equal(t, lisp.List{nil, table}, args)
+
+	args, samples, ok = patchSmoothing(lisp.List{nil, lisp.Keyword("cutoff"), 300, ms})
+	require.True(t, ok)
+	require.Equal(t, 441, samples)
+	require.Equal(t, lisp.List{nil, lisp.Keyword("cutoff"), 300}, args)
+
+	args, _, ok = patchSmoothing(lisp.List{nil, lisp.Keyword("time"), ms})
+	require.False(t, ok)
+	require.Len(t, args, 3)
+
+	_, _, ok = patchSmoothing(lisp.List{nil, table})
+	require.False(t, ok)
+}
@@ -4,10 +4,10 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newAdjust(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &adjust{
-		in:   io.NewIn("in", dsp.Float64(0)),
-		mult: io.NewIn("mult", dsp.Float64(1)),
-		add:  io.NewIn("add", dsp.Float64(0)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithDoc("signal to adjust")),
+		mult: io.NewIn("mult", dsp.Float64(1), WithDoc("multiplier applied to the signal")),
+		add:  io.NewIn("add", dsp.Float64(0), WithDoc("offset added after multiplication")),
+		out:  io.NewOut("out", WithDoc("in * mult + add")),
 	}), nil
 }
 
@@ -10,17 +10,17 @@ func newAdsr(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &adsr{
 		state:       &adsrState{},
 		stateFunc:   adsrIdle,
-		gate:        io.NewIn("gate", dsp.Float64(0)),
-		attack:      io.NewIn("attack", dsp.Duration(50, c.SampleRate)),
-		decay:       io.NewIn("decay", dsp.Duration(50, c.SampleRate)),
-		sustain:     io.NewIn("sustain", dsp.Float64(0.5)),
-		sustainHold: io.NewIn("sustain-hold", dsp.Duration(0, c.SampleRate)),
-		release:     io.NewIn("release", dsp.Duration(50, c.SampleRate)),
-		cycle:       io.NewIn("cycle", dsp.Float64(0)),
-		ratio:       io.NewIn("ratio", dsp.Float64(0.01)),
-		out:         io.NewOut("out"),
-		mirror:      io.NewOut("mirror"),
-		eoc:         io.NewOut("eoc"),
+		gate:        io.NewIn("gate", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("starts the attack and holds at the sustain level while high")),
+		attack:      io.NewIn("attack", dsp.Duration(50, c.SampleRate), WithDoc("duration of the attack")),
+		decay:       io.NewIn("decay", dsp.Duration(50, c.SampleRate), WithDoc("duration of the decay")),
+		sustain:     io.NewIn("sustain", dsp.Float64(0.5), WithRange(0, 1), WithDoc("level held after the decay")),
+		sustainHold: io.NewIn("sustain-hold", dsp.Duration(0, c.SampleRate), WithDoc("time the sustain level is held after the gate falls")),
+		release:     io.NewIn("release", dsp.Duration(50, c.SampleRate), WithDoc("duration of the release")),
+		cycle:       io.NewIn("cycle", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("loops the envelope while high")),
+		ratio:       io.NewIn("ratio", dsp.Float64(0.01), WithDoc("curvature of the segments")),
+		out:         io.NewOut("out", WithRange(0, 1), WithDoc("envelope")),
+		mirror:      io.NewOut("mirror", WithRange(0, 1), WithDoc("inverted envelope")),
+		eoc:         io.NewOut("eoc", WithMeasure(MeasureGate), WithDoc("high at the end of the cycle")),
 	}), nil
 }
 
@@ -34,6 +34,23 @@ type adsr struct {
 
 func (s *adsr) ProcessSample(i int) {
 	s.state.gate = s.gate.Read(i)
+	s.read(i)
+	s.tick(i)
+}
+
+// ProcessControl implements ControlProcessor. Durations are converted from samples to control periods.
+func (s *adsr) ProcessControl(n int) {
+	s.state.gate = s.gate.readPeak(n)
+	s.read(0)
+	scale := 1 / float64(n)
+	s.state.attack *= scale
+	s.state.decay *= scale
+	s.state.sustainHold *= scale
+	s.state.release *= scale
+	s.tick(0)
+}
+
+func (s *adsr) read(i int) {
 	s.state.attack = s.attack.Read(i)
 	s.state.decay = s.decay.Read(i)
 	s.state.sustain = s.sustain.Read(i)
@@ -41,6 +58,9 @@ func (s *adsr) ProcessSample(i int) {
 	s.state.release = s.release.Read(i)
 	s.state.cycle = s.cycle.Read(i)
 	s.state.ratio = s.ratio.Read(i)
+}
+
+func (s *adsr) tick(i int) {
 	s.stateFunc = s.stateFunc(s.state)
 	s.state.lastGate = s.state.gate
 
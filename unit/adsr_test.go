@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
 )
 
 func TestADSR(t *testing.T) {
@@ -59,3 +61,35 @@ func TestADSR(t *testing.T) {
 		}
 	}
 }
+
+func TestADSR_ControlRate(t *testing.T) {
+	builder := Builders()["adsr"]
+	u, err := builder(Config{
+		Values:    map[string]any{"rate": "control"},
+		FrameSize: frameSize,
+	})
+	require.NoError(t, err)
+
+	var (
+		gate   = u.In["gate"]
+		attack = u.In["attack"]
+		decay  = u.In["decay"]
+		out    = u.Out["out"].Out()
+	)
+
+	// Attack lasts roughly four frames regardless of being processed once per frame
+	attack.Fill(dsp.Float64(4 * frameSize))
+	decay.Fill(dsp.Float64(100 * frameSize))
+	gate.Write(frameSize/2, 1)
+
+	var frames int
+	for ; frames < 10; frames++ {
+		u.ProcessFrame(frameSize)
+		if out.Read(frameSize-1) >= 1 {
+			break
+		}
+		gate.Fill(dsp.Float64(1))
+	}
+	require.Equal(t, 4, frames)
+	require.True(t, out.Read(frameSize-1) > out.Read(0))
+}
@@ -643,8 +643,7 @@ func TestAllUnits(t *testing.T) {
 			},
 		},
 		{
-			unit:         "latch",
-			configValues: map[string]interface{}{"size": 4},
+			unit: "latch",
 			scenario: []scenario{
 				{
 					inputs: map[string][]float64{
@@ -1183,7 +1182,7 @@ func (s scenario) TestUnit(t *testing.T, index int, u *Unit) {
 func repeatControl(s []float64) []float64 {
 	var ss []float64
 	for _, v := range s {
-		for i := 0; i < controlPeriod; i++ {
+		for i := 0; i < DefaultControlPeriod; i++ {
 			ss = append(ss, v)
 		}
 	}
@@ -1194,7 +1193,7 @@ func padControl(s []float64) []float64 {
 	var ss []float64
 	for _, v := range s {
 		ss = append(ss, v)
-		for i := 0; i < controlPeriod-1; i++ {
+		for i := 0; i < DefaultControlPeriod-1; i++ {
 			ss = append(ss, 0)
 		}
 	}
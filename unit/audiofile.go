@@ -0,0 +1,376 @@
+package unit
+
+import (
+	"io"
+	"math"
+	"os"
+
+	"github.com/go-audio/aiff"
+	"github.com/go-audio/wav"
+	"github.com/mewkiz/flac"
+
+	"github.com/brettbuddin/shaden/errors"
+)
+
+// WAV format tags
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xfffe
+)
+
+type audioFormat struct {
+	channels, sampleRate int
+	// frames is the number of frames in the file, or 0 if it isn't known up front.
+	frames int
+}
+
+// audioFile decodes an audio file a block of frames at a time. Samples of each channel are interleaved and scaled to
+// the range [-1, 1].
+type audioFile interface {
+	io.Closer
+	format() audioFormat
+	// read decodes frames into dst, which must hold a whole number of frames, and returns how many frames it decoded.
+	// It returns io.EOF once there are no frames left.
+	read(dst []float64) (int, error)
+	// seek moves to a frame.
+	seek(frame int) error
+}
+
+// openAudioFile opens a WAV, AIFF or FLAC file for decoding. The format is detected from the contents of the file
+// rather than its name.
+func openAudioFile(path string) (audioFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var magic [4]byte
+	if _, err := io.ReadFull(f, magic[:]); err != nil {
+		f.Close()
+		return nil, errors.Errorf("%q is not a WAV, AIFF or FLAC file", path)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	var af audioFile
+	switch string(magic[:]) {
+	case "RIFF":
+		af, err = openWAV(f)
+	case "FORM":
+		af, err = openAIFF(f)
+	case "fLaC":
+		af, err = openFLAC(f)
+	default:
+		err = errors.New("unknown format")
+	}
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "%q is not a WAV, AIFF or FLAC file", path)
+	}
+	return af, nil
+}
+
+// pcmFile reads uncompressed samples straight from the data chunk of a WAV or AIFF file.
+type pcmFile struct {
+	f              *os.File
+	data           *io.SectionReader
+	fmt            audioFormat
+	bytesPerSample int
+	bigEndian      bool
+	unsigned8      bool // 8-bit samples are unsigned (WAV) rather than signed (AIFF)
+	float          bool
+	raw            []byte
+}
+
+func openWAV(f *os.File) (*pcmFile, error) {
+	d := wav.NewDecoder(f)
+	if !d.IsValidFile() {
+		return nil, errors.New("invalid header")
+	}
+	if err := d.FwdToPCM(); err != nil {
+		return nil, err
+	}
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+
+	format := d.WavAudioFormat
+	if format == wavFormatExtensible {
+		var err error
+		if format, err = wavSubFormat(f); err != nil {
+			return nil, err
+		}
+	}
+	switch format {
+	case wavFormatPCM:
+	case wavFormatFloat:
+		if d.BitDepth != 32 {
+			return nil, errors.Errorf("unsupported floating-point bit depth %d", d.BitDepth)
+		}
+	default:
+		return nil, errors.Errorf("unsupported WAV format %#x", format)
+	}
+
+	p := &pcmFile{
+		f:         f,
+		unsigned8: true,
+		float:     format == wavFormatFloat,
+	}
+	return p, p.init(int(d.NumChans), int(d.SampleRate), int(d.BitDepth), int64(d.PCMSize))
+}
+
+// wavSubFormat returns the format tag of the samples in a WAVE_FORMAT_EXTENSIBLE file, which is held in the first two
+// bytes of the subformat GUID at the end of the fmt chunk. The position of the file is left alone.
+func wavSubFormat(f *os.File) (uint16, error) {
+	var header [8]byte
+	for off := int64(12); ; {
+		if _, err := f.ReadAt(header[:], off); err != nil {
+			return 0, errors.New("missing fmt chunk")
+		}
+		size := int64(header[4]) | int64(header[5])<<8 | int64(header[6])<<16 | int64(header[7])<<24
+		if string(header[:4]) != "fmt " {
+			off += 8 + size + size%2
+			continue
+		}
+		if size < 40 {
+			return 0, errors.New("extensible fmt chunk is too short")
+		}
+		var tag [2]byte
+		if _, err := f.ReadAt(tag[:], off+8+24); err != nil {
+			return 0, err
+		}
+		return uint16(tag[0]) | uint16(tag[1])<<8, nil
+	}
+}
+
+func openAIFF(f *os.File) (*pcmFile, error) {
+	d := aiff.NewDecoder(f)
+	if !d.IsValidFile() {
+		return nil, errors.New("invalid header")
+	}
+	if err := d.FwdToPCM(); err != nil {
+		return nil, err
+	}
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+
+	p := &pcmFile{f: f, bigEndian: string(d.Encoding[:]) != "sowt"}
+	blockAlign := int64(d.NumChans) * int64((d.BitDepth+7)/8)
+	return p, p.init(int(d.NumChans), d.SampleRate, int(d.BitDepth), int64(d.NumSampleFrames)*blockAlign)
+}
+
+// init sets up reading of the data chunk, which starts at the current position of the file.
+func (p *pcmFile) init(channels, rate, bitDepth int, size int64) error {
+	if channels < 1 {
+		return errors.New("no channels")
+	}
+	p.bytesPerSample = (bitDepth + 7) / 8
+	if p.bytesPerSample < 1 || p.bytesPerSample > 4 {
+		return errors.Errorf("unsupported bit depth %d", bitDepth)
+	}
+	start, err := p.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if info, err := p.f.Stat(); err == nil && start+size > info.Size() {
+		size = info.Size() - start
+	}
+
+	blockAlign := int64(channels * p.bytesPerSample)
+	p.data = io.NewSectionReader(p.f, start, size-size%blockAlign)
+	p.fmt = audioFormat{
+		channels:   channels,
+		sampleRate: rate,
+		frames:     int(size / blockAlign),
+	}
+	return nil
+}
+
+func (p *pcmFile) format() audioFormat { return p.fmt }
+func (p *pcmFile) Close() error        { return p.f.Close() }
+
+func (p *pcmFile) seek(frame int) error {
+	_, err := p.data.Seek(int64(frame*p.fmt.channels*p.bytesPerSample), io.SeekStart)
+	return err
+}
+
+func (p *pcmFile) read(dst []float64) (int, error) {
+	if size := len(dst) * p.bytesPerSample; len(p.raw) < size {
+		p.raw = make([]byte, size)
+	}
+	n, err := io.ReadFull(p.data, p.raw[:len(dst)*p.bytesPerSample])
+	frames := n / (p.fmt.channels * p.bytesPerSample)
+	if frames == 0 {
+		if err == nil || err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return 0, err
+	}
+
+	scale := 1 / math.Pow(2, float64(8*p.bytesPerSample-1))
+	for i := range dst[:frames*p.fmt.channels] {
+		b := p.raw[i*p.bytesPerSample : (i+1)*p.bytesPerSample]
+		dst[i] = p.sample(b, scale)
+	}
+	return frames, nil
+}
+
+func (p *pcmFile) sample(b []byte, scale float64) float64 {
+	if len(b) == 1 {
+		if p.unsigned8 {
+			return float64(int(b[0])-128) * scale
+		}
+		return float64(int8(b[0])) * scale
+	}
+
+	// Assemble the sample big-endian into the top of a 32-bit word so its sign is preserved.
+	var v uint32
+	for j := range b {
+		k := j
+		if !p.bigEndian {
+			k = len(b) - 1 - j
+		}
+		v |= uint32(b[k]) << uint(8*(3-j))
+	}
+	if p.float {
+		return float64(math.Float32frombits(v))
+	}
+	return float64(int32(v)>>uint(8*(4-len(b)))) * scale
+}
+
+// flacFile decodes a FLAC file a frame at a time, holding on to the part of the last decoded frame that hasn't been
+// read yet.
+type flacFile struct {
+	f       *os.File
+	stream  *flac.Stream
+	fmt     audioFormat
+	scale   float64
+	pending []float64
+	decoded []float64
+}
+
+func openFLAC(f *os.File) (*flacFile, error) {
+	stream, err := flac.NewSeek(f)
+	if err != nil {
+		return nil, err
+	}
+	info := stream.Info
+	if info.NChannels < 1 || info.BitsPerSample < 1 {
+		return nil, errors.New("invalid stream info")
+	}
+	return &flacFile{
+		f:      f,
+		stream: stream,
+		scale:  1 / math.Pow(2, float64(info.BitsPerSample-1)),
+		fmt: audioFormat{
+			channels:   int(info.NChannels),
+			sampleRate: int(info.SampleRate),
+			frames:     int(info.NSamples),
+		},
+	}, nil
+}
+
+func (ff *flacFile) format() audioFormat { return ff.fmt }
+func (ff *flacFile) Close() error        { return ff.f.Close() }
+
+func (ff *flacFile) seek(frame int) error {
+	ff.pending = nil
+
+	// The decoder miscounts the position of a final frame that's shorter than the rest, and fails to seek into it.
+	// Seeking a block early and decoding forward from there works around it.
+	if _, err := ff.stream.Seek(uint64(frame)); err != nil {
+		early := frame - int(ff.stream.Info.BlockSizeMax)
+		if early < 0 {
+			early = 0
+		}
+		if _, err := ff.stream.Seek(uint64(early)); err != nil {
+			return err
+		}
+	}
+	for {
+		start, err := ff.decode()
+		if err != nil {
+			return err
+		}
+		if skip := frame - start; skip < len(ff.pending)/ff.fmt.channels {
+			if skip > 0 {
+				ff.pending = ff.pending[skip*ff.fmt.channels:]
+			}
+			return nil
+		}
+	}
+}
+
+func (ff *flacFile) read(dst []float64) (int, error) {
+	var n int
+	for n < len(dst) {
+		if len(ff.pending) == 0 {
+			if _, err := ff.decode(); err == io.EOF {
+				break
+			} else if err != nil {
+				return n / ff.fmt.channels, err
+			}
+		}
+		m := copy(dst[n:], ff.pending)
+		ff.pending = ff.pending[m:]
+		n += m
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n / ff.fmt.channels, nil
+}
+
+// decode parses the next frame of the stream into pending and returns the position of its first sample.
+func (ff *flacFile) decode() (int, error) {
+	frame, err := ff.stream.ParseNext()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		channels = ff.fmt.channels
+		size     = int(frame.BlockSize)
+	)
+	if len(frame.Subframes) != channels {
+		return 0, errors.Errorf("frame has %d channels; expected %d", len(frame.Subframes), channels)
+	}
+	if cap(ff.decoded) < size*channels {
+		ff.decoded = make([]float64, size*channels)
+	}
+	ff.decoded = ff.decoded[:size*channels]
+	for c, sub := range frame.Subframes {
+		for i, s := range sub.Samples[:size] {
+			ff.decoded[i*channels+c] = float64(s) * ff.scale
+		}
+	}
+	ff.pending = ff.decoded
+
+	// Frames of streams with a fixed block size are numbered by frame rather than by sample.
+	if frame.HasFixedBlockSize {
+		return int(frame.Num) * int(ff.stream.Info.BlockSizeMax), nil
+	}
+	return int(frame.Num), nil
+}
+
+// readAudioFile decodes every frame of an audio file.
+func readAudioFile(af audioFile) ([]float64, error) {
+	var (
+		format = af.format()
+		block  = make([]float64, 4096*format.channels)
+		out    = make([]float64, 0, format.frames*format.channels)
+	)
+	for {
+		n, err := af.read(block)
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, block[:n*format.channels]...)
+	}
+}
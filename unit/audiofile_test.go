@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBuffer_Formats(t *testing.T) {
+	samples := make([]float64, 6000)
+	for i := range samples {
+		samples[i] = 0.8 * math.Sin(float64(i)/50)
+	}
+
+	for _, test := range []struct {
+		name  string
+		write func(*testing.T, int, int, []float64) string
+	}{
+		{"wav", writeTestWAV},
+		{"aiff", writeTestAIFF},
+		{"flac", writeTestFLAC},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := LoadBuffer(test.write(t, 48000, 2, samples))
+			require.NoError(t, err)
+			require.Equal(t, 2, b.Channels)
+			require.Equal(t, 48000, b.SampleRate)
+			require.Equal(t, 3000, b.Frames())
+			for i, s := range samples {
+				require.InDelta(t, s, b.Data[i], 1e-4)
+			}
+		})
+	}
+}
+
+func TestAudioFile_Seek(t *testing.T) {
+	samples := make([]float64, 5000)
+	for i := range samples {
+		samples[i] = float64(i%1000) / 1000
+	}
+
+	for _, path := range []string{
+		writeTestWAV(t, sampleRate, 1, samples),
+		writeTestAIFF(t, sampleRate, 1, samples),
+		writeTestFLAC(t, sampleRate, 1, samples),
+	} {
+		af, err := openAudioFile(path)
+		require.NoError(t, err)
+		require.Equal(t, 5000, af.format().frames)
+
+		require.NoError(t, af.seek(2500))
+		dst := make([]float64, 10)
+		n, err := af.read(dst)
+		require.NoError(t, err)
+		require.Equal(t, 10, n)
+		require.InDelta(t, 0.5, dst[0], 1e-4)
+		require.InDelta(t, 0.509, dst[9], 1e-4)
+
+		require.NoError(t, af.seek(4995))
+		n, err = af.read(dst)
+		require.NoError(t, err)
+		require.Equal(t, 5, n)
+		require.InDelta(t, 0.995, dst[0], 1e-4)
+		_, err = af.read(dst)
+		require.Equal(t, io.EOF, err)
+		require.NoError(t, af.Close())
+	}
+}
+
+// appendLE appends the lowest n bytes of v to b, least significant first.
+func appendLE(b []byte, n int, v uint32) []byte {
+	for i := 0; i < n; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+// writeExtensibleWAV writes mono samples to a WAVE_FORMAT_EXTENSIBLE file whose subformat is either PCM (16-bit) or
+// floating-point (32-bit).
+func writeExtensibleWAV(t *testing.T, subFormat uint16, samples []float64) string {
+	bits := 16
+	if subFormat == wavFormatFloat {
+		bits = 32
+	}
+	var data []byte
+	for _, s := range samples {
+		if subFormat == wavFormatFloat {
+			data = appendLE(data, 4, math.Float32bits(float32(s)))
+		} else {
+			data = appendLE(data, 2, uint32(int16(math.Round(s*32767))))
+		}
+	}
+
+	fmtChunk := appendLE(nil, 2, wavFormatExtensible)
+	fmtChunk = appendLE(fmtChunk, 2, 1) // channels
+	fmtChunk = appendLE(fmtChunk, 4, sampleRate)
+	fmtChunk = appendLE(fmtChunk, 4, uint32(sampleRate*bits/8))
+	fmtChunk = appendLE(fmtChunk, 2, uint32(bits/8))
+	fmtChunk = appendLE(fmtChunk, 2, uint32(bits))
+	fmtChunk = appendLE(fmtChunk, 2, 22) // size of the extension
+	fmtChunk = appendLE(fmtChunk, 2, uint32(bits))
+	fmtChunk = appendLE(fmtChunk, 4, 4) // front center
+	fmtChunk = appendLE(fmtChunk, 2, uint32(subFormat))
+	fmtChunk = append(fmtChunk, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71)
+
+	var b []byte
+	b = append(b, "RIFF"...)
+	b = appendLE(b, 4, uint32(4+8+len(fmtChunk)+8+len(data)))
+	b = append(b, "WAVEfmt "...)
+	b = appendLE(b, 4, uint32(len(fmtChunk)))
+	b = append(b, fmtChunk...)
+	b = append(b, "data"...)
+	b = appendLE(b, 4, uint32(len(data)))
+	b = append(b, data...)
+
+	path := filepath.Join(t.TempDir(), "extensible.wav")
+	require.NoError(t, os.WriteFile(path, b, 0644))
+	return path
+}
+
+func TestLoadBuffer_ExtensibleWAV(t *testing.T) {
+	samples := []float64{0, 0.25, -0.5, 0.75, -1}
+	for _, subFormat := range []uint16{wavFormatPCM, wavFormatFloat} {
+		b, err := LoadBuffer(writeExtensibleWAV(t, subFormat, samples))
+		require.NoError(t, err)
+		require.Equal(t, len(samples), b.Frames())
+		for i, s := range samples {
+			require.InDelta(t, s, b.Data[i], 1e-4, "subformat %d, sample %d", subFormat, i)
+		}
+	}
+}
+
+func TestLoadBuffer_UnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(path, []byte("not audio"), 0644))
+	_, err := LoadBuffer(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not a WAV, AIFF or FLAC file")
+}
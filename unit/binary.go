@@ -8,10 +8,10 @@ import (
 
 func newBinary(io *IO, op binaryOp) (*Unit, error) {
 	return NewUnit(io, &binary{
-		x:   io.NewIn("x", dsp.Float64(0)),
-		y:   io.NewIn("y", dsp.Float64(0)),
+		x:   io.NewIn("x", dsp.Float64(0), WithDoc("first operand")),
+		y:   io.NewIn("y", dsp.Float64(0), WithDoc("second operand")),
 		op:  op,
-		out: io.NewOut("out"),
+		out: io.NewOut("out", WithDoc("result of the operation")),
 	}), nil
 }
 
@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// bowAttack is the time constant, in seconds, of the bow's speed when it starts and stops moving.
+const bowAttack = 0.01
+
+func newBow(io *IO, c Config) (*Unit, error) {
+	size := waveguideSize(c.SampleRate)
+	return NewUnit(io, &bow{
+		pitch:      io.NewIn("pitch", dsp.Frequency(220, c.SampleRate), WithDoc("frequency of the string")),
+		gate:       io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("bows the string while high")),
+		level:      io.NewIn("level", dsp.Float64(1), WithRange(0, 1), WithDoc("speed of the bow")),
+		pressure:   io.NewIn("pressure", dsp.Float64(0.5), WithRange(0, 1), WithDoc("force of the bow against the string")),
+		damping:    io.NewIn("damping", dsp.Float64(0.3), WithRange(0, 1), WithDoc("how quickly the string decays")),
+		brightness: io.NewIn("brightness", dsp.Float64(0.5), WithRange(0, 1), WithDoc("how much of the high end of the string lasts")),
+		position:   io.NewIn("position", dsp.Float64(0.13), WithRange(0, 1), WithDoc("where along the string it's bowed, measured from the bridge")),
+		out:        io.NewOut("out", WithDoc("vibration of the string at the bridge")),
+		neck:       newWaveguideDelay(size),
+		bridge:     newWaveguideDelay(size),
+		block:      &dsp.DCBlock{},
+		size:       size,
+		sampleRate: float64(c.SampleRate),
+		attack:     1 - math.Exp(-1/(bowAttack*float64(c.SampleRate))),
+	}), nil
+}
+
+// bow is a bowed string. The bow divides the string into two waveguides, one to the neck and one to the bridge, which
+// are both inverted as they reflect off of the ends. Where the bow meets the string, it sticks to or slips along the
+// string depending on the difference between their speeds, following the bow table of the Synthesis ToolKit. The bow is
+// lifted from the string when the gate goes low, leaving the string to ring out.
+type bow struct {
+	pitch, gate, level, pressure  *In
+	damping, brightness, position *In
+	out                           *Out
+	neck, bridge                  *waveguideDelay
+	loss                          waveguideLoss
+	block                         *dsp.DCBlock
+	size                          int
+	sampleRate, attack            float64
+	velocity, contact             float64
+	neckOut, bridgeOut            float64
+}
+
+func (b *bow) ProcessSample(i int) {
+	var (
+		period   = waveguidePeriod(b.pitch.Read(i), b.size)
+		weight   = waveguideWeight(b.brightness.Read(i))
+		gain     = waveguideGain(b.damping.Read(i), period, b.sampleRate)
+		position = dsp.Clamp(b.position.Read(i), 0.02, 0.98)
+		speed    float64
+		contact  float64
+	)
+	if b.gate.Read(i) > 0 {
+		speed = 0.03 + 0.2*dsp.Clamp(b.level.Read(i), 0, 1)
+		contact = 1
+	}
+	b.velocity += (speed - b.velocity) * b.attack
+	b.contact += (contact - b.contact) * b.attack
+
+	var (
+		bridgeRefl = -b.loss.tick(b.bridgeOut, weight, gain)
+		neckRefl   = -b.neckOut
+		diff       = b.velocity - (bridgeRefl + neckRefl)
+		slope      = 5 - 4*dsp.Clamp(b.pressure.Read(i), 0, 1)
+		friction   = dsp.Clamp(math.Pow(math.Abs(diff*slope)+0.75, -4), 0.01, 0.98)
+		excite     = diff * friction * b.contact
+	)
+
+	// Each half of the string is a sample longer than its delay, as the reflections are taken from the previous
+	// sample.
+	length := period - weight - 2
+	b.neckOut = b.neck.tick(bridgeRefl+excite, length*(1-position))
+	b.bridgeOut = b.bridge.tick(neckRefl+excite, length*position)
+	b.out.Write(i, b.block.Tick(b.bridgeOut))
+}
@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"math"
+	"os"
+	"sync"
+
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+
+	"github.com/brettbuddin/shaden/errors"
+)
+
+// Buffer is decoded audio that can be shared between Units. Samples of each channel are interleaved in Data. Units
+// only ever read from a shared Buffer.
+type Buffer struct {
+	Data                 []float64
+	Channels, SampleRate int
+	// Path is the file the Buffer was loaded from, if any.
+	Path string
+
+	monoOnce sync.Once
+	mono     []float64
+}
+
+// LoadBuffer decodes a WAV, AIFF or FLAC file into floating-point samples in the range [-1, 1].
+func LoadBuffer(path string) (*Buffer, error) {
+	af, err := openAudioFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer af.Close()
+
+	data, err := readAudioFile(af)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding %q", path)
+	}
+
+	format := af.format()
+	return &Buffer{
+		Data:       data,
+		Channels:   format.channels,
+		SampleRate: format.sampleRate,
+		Path:       path,
+	}, nil
+}
+
+// Frames returns the number of frames (samples of every channel) in the Buffer.
+func (b *Buffer) Frames() int {
+	if b.Channels == 0 {
+		return 0
+	}
+	return len(b.Data) / b.Channels
+}
+
+// Mono returns the Buffer's samples with all channels averaged together. The result is computed once and shared.
+func (b *Buffer) Mono() []float64 {
+	if b.Channels <= 1 {
+		return b.Data
+	}
+	b.monoOnce.Do(func() {
+		b.mono = make([]float64, b.Frames())
+		for i := range b.mono {
+			var sum float64
+			for c := 0; c < b.Channels; c++ {
+				sum += b.Data[i*b.Channels+c]
+			}
+			b.mono[i] = sum / float64(b.Channels)
+		}
+	})
+	return b.mono
+}
+
+// SaveWAV writes the Buffer to a 24-bit WAV file. Samples outside of [-1, 1] are clipped.
+func (b *Buffer) SaveWAV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	const bitDepth = 24
+	var (
+		scale = math.Pow(2, bitDepth-1) - 1
+		data  = make([]int, len(b.Data))
+	)
+	for i, s := range b.Data {
+		data[i] = int(math.Round(math.Max(-1, math.Min(1, s)) * scale))
+	}
+
+	enc := wav.NewEncoder(f, b.SampleRate, bitDepth, b.Channels, 1)
+	err = enc.Write(&audio.IntBuffer{
+		Format:         &audio.Format{NumChannels: b.Channels, SampleRate: b.SampleRate},
+		Data:           data,
+		SourceBitDepth: bitDepth,
+	})
+	if err == nil {
+		err = enc.Close()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "writing %q", path)
+	}
+	return nil
+}
+
+// BufferSource is implemented by Units that hold audio that can be captured as a Buffer (e.g. the recording of a
+// looper). Buffer returns a copy and must only be called while the Unit isn't being processed.
+type BufferSource interface {
+	Buffer() *Buffer
+}
+
+// Buffers is a set of named Buffers shared between Units. It's safe for concurrent use.
+type Buffers struct {
+	mu      sync.RWMutex
+	buffers map[string]*Buffer
+}
+
+// NewBuffers returns an empty set of Buffers.
+func NewBuffers() *Buffers {
+	return &Buffers{buffers: map[string]*Buffer{}}
+}
+
+// Load decodes a file into a Buffer stored under a name. Loading the same file under the same name again reuses the
+// existing Buffer rather than decoding the file again.
+func (bs *Buffers) Load(name, path string) (*Buffer, error) {
+	if b, ok := bs.Get(name); ok && b.Path == path {
+		return b, nil
+	}
+	b, err := LoadBuffer(path)
+	if err != nil {
+		return nil, err
+	}
+	bs.Set(name, b)
+	return b, nil
+}
+
+// Set stores a Buffer under a name, replacing any Buffer already stored under it. Units built from the replaced
+// Buffer continue to use it.
+func (bs *Buffers) Set(name string, b *Buffer) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.buffers[name] = b
+}
+
+// Get returns the Buffer stored under a name.
+func (bs *Buffers) Get(name string) (*Buffer, bool) {
+	if bs == nil {
+		return nil, false
+	}
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	b, ok := bs.buffers[name]
+	return b, ok
+}
+
+// Remove forgets the Buffer stored under a name.
+func (bs *Buffers) Remove(name string) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	delete(bs.buffers, name)
+}
+
+// Names returns the names of all stored Buffers.
+func (bs *Buffers) Names() []string {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	names := make([]string, 0, len(bs.buffers))
+	for name := range bs.buffers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// sampleRateRatio returns the playback speed that plays audio recorded at one sample rate at its original pitch in
+// another. Unknown rates are assumed to match.
+func sampleRateRatio(from, to int) float64 {
+	if from <= 0 || to <= 0 {
+		return 1
+	}
+	return float64(from) / float64(to)
+}
@@ -0,0 +1,68 @@
+package unit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+func TestBuffer_SaveAndLoad(t *testing.T) {
+	b := &Buffer{
+		Data:       []float64{0, 0.5, -0.5, 1, -1, 2},
+		Channels:   2,
+		SampleRate: 48000,
+	}
+	require.Equal(t, 3, b.Frames())
+	require.Equal(t, []float64{0.25, 0.25, 0.5}, b.Mono())
+
+	path := filepath.Join(t.TempDir(), "out.wav")
+	require.NoError(t, b.SaveWAV(path))
+
+	loaded, err := LoadBuffer(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, loaded.Channels)
+	require.Equal(t, 48000, loaded.SampleRate)
+	require.Equal(t, path, loaded.Path)
+	expected := []float64{0, 0.5, -0.5, 1, -1, 1} // clipped
+	require.Len(t, loaded.Data, len(expected))
+	for i, v := range expected {
+		require.InDelta(t, v, loaded.Data[i], 1e-6)
+	}
+}
+
+func TestBuffers(t *testing.T) {
+	path := writeTestWAV(t, sampleRate, 1, []float64{0.5, 0.25})
+
+	bs := NewBuffers()
+	b, err := bs.Load("x", path)
+	require.NoError(t, err)
+	again, err := bs.Load("x", path)
+	require.NoError(t, err)
+	require.True(t, b == again)
+	require.Equal(t, []string{"x"}, bs.Names())
+
+	// Units share the Buffer rather than loading their own copy.
+	u, err := Builders()["sample"](Config{
+		Values:     map[string]any{"buffer": "x"},
+		Buffers:    bs,
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.True(t, &b.Data[0] == &u.SampleProcessor.(*wavSample).playhead.data[0])
+
+	bs.Remove("x")
+	_, ok := bs.Get("x")
+	require.False(t, ok)
+
+	_, err = Builders()["sample"](Config{Values: map[string]any{"buffer": "x"}, Buffers: bs})
+	require.EqualError(t, err, `unit/sample: unknown buffer "x"`)
+	_, err = Builders()["sample"](Config{Values: map[string]any{"buffer": "x", "file": path}, Buffers: bs})
+	require.EqualError(t, err, "unit/sample: only one of file or buffer can be specified")
+	_, err = Builders()["sample"](Config{})
+	require.EqualError(t, err, "unit/sample: no file or buffer specified")
+}
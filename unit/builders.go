@@ -1,9 +1,10 @@
 package unit
 
 import (
-	"math/rand"
+	"strings"
+	"sync"
 
-	"github.com/mitchellh/mapstructure"
+	"github.com/brettbuddin/shaden/errors"
 )
 
 var (
@@ -36,59 +37,94 @@ var (
 
 		"adjust":             newAdjust,
 		"adsr":               newAdsr,
+		"bow":                newBow,
 		"center":             newCenter,
 		"chance":             newChance,
 		"chebyshev":          newChebyshev,
+		"chorus":             newChorus,
 		"clip":               newClip,
 		"clock":              newClock,
 		"clock-div":          newClockDiv,
 		"clock-mult":         newClockMult,
 		"cluster":            newCluster,
 		"cond":               newCond,
+		"convolve":           newConvolve,
 		"count":              newCount,
 		"debug":              newDebug,
 		"decimate":           newDecimate,
 		"delay":              newDelay,
 		"demux":              newDemux,
 		"dynamics":           newDynamics,
+		"eq":                 newEQ,
 		"euclid":             newEuclid,
 		"filter":             newFilter,
 		"filter-bank":        newFilterBank,
+		"flanger":            newFlanger,
+		"fm":                 newFM,
 		"fold":               newFold,
+		"follow":             newFollow,
 		"gate":               newGate,
 		"gate-mix":           newGateMix,
 		"gate-series":        newGateSeries,
 		"gen":                newGen,
+		"granular":           newGranular,
 		"lag":                newLag,
 		"latch":              newLatch,
 		"lerp":               newInterpolate,
 		"logic":              newLogic,
+		"looper":             newLooper,
 		"low-gen":            newLowGen,
 		"midi-hz":            newMIDIToHz,
 		"mix":                newMix,
 		"morph":              newMorph,
 		"mux":                newMux,
+		"onset":              newOnset,
 		"overload":           newOverload,
 		"pan":                newPan,
 		"panmix":             newPanMix,
+		"phaser":             newPhaser,
 		"pitch":              newPitch,
+		"pitch-track":        newPitchTrack,
+		"pluck":              newPluck,
 		"quantize":           newQuantize,
 		"random-series":      newRandomSeries,
 		"rcd":                newRCD,
 		"reverb":             newReverb,
 		"sample":             newWAVSample,
+		"sampler":            newSampler,
 		"shift":              newShift,
 		"slope":              newSlope,
 		"smooth":             newSmooth,
+		"spectral-blur":      newSpectralBlur,
+		"spectral-freeze":    newSpectralFreeze,
+		"spectral-gate":      newSpectralGate,
 		"stages":             newStages,
 		"switch":             newSwitch,
+		"tape-delay":         newTapeDelay,
 		"toggle":             newToggle,
 		"transpose":          newTranspose,
 		"transpose-interval": newTransposeInterval,
 		"val-gate":           newValToGate,
+		"vocoder":            newVocoder,
+		"wavetable":          newWavetable,
 		"xfade":              newCrossfade,
 		"xfeed":              newCrossfeed,
 	}
+
+	// controlRateUnits are the names of the Units in this package that can be processed at control rate. Only they,
+	// and registered Units that can, accept the "rate" config key.
+	controlRateUnits = []string{"adsr", "low-gen", "slope"}
+
+	// reserved are the names of Units provided by other packages in this repository, which the runtime makes
+	// available alongside these.
+	reserved = map[string]bool{
+		"source":     true, // engine
+		"midi-clock": true, // midi
+		"midi-input": true, // midi
+	}
+
+	registryMu sync.RWMutex
+	registry   = map[string]IOBuilder{}
 )
 
 // IOBuilder provides an IO, containing identifying information, for a Unit to be constructed around.
@@ -97,21 +133,32 @@ type IOBuilder func(*IO, Config) (*Unit, error)
 // Builder constructs a Unit of some type.
 type Builder func(Config) (*Unit, error)
 
-// Config is a map that's used to provide configuration options to Builders.
-type Config struct {
-	Values                map[string]any
-	Rand                  *rand.Rand
-	SampleRate, FrameSize int
+// Builders returns all Builders for all Units provided by this package.
+func Builders() map[string]Builder {
+	return PrepareBuilders(builders)
 }
 
-// Decode loads a struct with the contents of the raw Config object.
-func (c Config) Decode(v any) error {
-	return mapstructure.Decode(c.Values, v)
+// Register makes an IOBuilder available under a specific name alongside the Units provided by this package. It's
+// intended to be called from the init function of a package that provides Units outside of this repository.
+// Registering a name twice, or a name already used by a Unit in this repository, is an error.
+func Register(name string, b IOBuilder) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := builders[name]; ok || reserved[name] {
+		return errors.Errorf("duplicate unit named %q", name)
+	}
+	if _, ok := registry[name]; ok {
+		return errors.Errorf("duplicate unit named %q", name)
+	}
+	registry[name] = b
+	return nil
 }
 
-// Builders returns all Builders for all Units provided by this package.
-func Builders() map[string]Builder {
-	return PrepareBuilders(builders)
+// RegisteredBuilders returns all Builders for Units that have been added with Register.
+func RegisteredBuilders() map[string]Builder {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return PrepareBuilders(registry)
 }
 
 // PrepareBuilders converts a set of IOBuilders to a set of Builders.
@@ -120,7 +167,35 @@ func PrepareBuilders(builders map[string]IOBuilder) map[string]Builder {
 	for k, v := range builders {
 		m[k] = func(typ string, f IOBuilder) Builder {
 			return func(c Config) (*Unit, error) {
-				return f(NewIO(typ, c.FrameSize), c)
+				c.typ = typ
+				c.decoded = new(bool)
+				rate, setRate, err := c.takeRate()
+				if err != nil {
+					return nil, err
+				}
+
+				io := NewIO(typ, c.FrameSize)
+				io.SetControlPeriod(c.ControlPeriod)
+				u, err := f(io, c)
+				if err != nil {
+					return nil, err
+				}
+				if !*c.decoded && len(c.Values) > 0 {
+					u.Close()
+					return nil, c.unknownKeyError(firstKey(c.Values), nil)
+				}
+				if setRate {
+					if !u.SupportsControlRate() {
+						u.Close()
+						return nil, c.errorf("rate is only accepted by units that can be processed at control rate (%s)",
+							strings.Join(controlRateUnits, ", "))
+					}
+					if err := u.SetRate(rate); err != nil {
+						u.Close()
+						return nil, err
+					}
+				}
+				return u, nil
 			}
 		}(k, v)
 	}
@@ -4,8 +4,8 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newCenter(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &center{
-		in:    io.NewIn("in", dsp.Float64(0)),
-		out:   io.NewOut("out"),
+		in:    io.NewIn("in", dsp.Float64(0), WithDoc("signal to remove DC offset from")),
+		out:   io.NewOut("out", WithDoc("signal centered around zero")),
 		block: &dsp.DCBlock{},
 	}), nil
 }
@@ -9,10 +9,10 @@ import (
 func newChance(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &chance{
 		rand: c.Rand,
-		in:   io.NewIn("in", dsp.Float64(0)),
-		bias: io.NewIn("bias", dsp.Float64(0)),
-		a:    io.NewOut("a"),
-		b:    io.NewOut("b"),
+		in:   io.NewIn("in", dsp.Float64(0), WithMeasure(MeasureTrigger), WithDoc("trigger that flips the coin")),
+		bias: io.NewIn("bias", dsp.Float64(0), WithRange(-1, 1), WithDoc("favors output a (-1) or output b (1)")),
+		a:    io.NewOut("a", WithMeasure(MeasureGate), WithDoc("high when the coin lands on a")),
+		b:    io.NewOut("b", WithMeasure(MeasureGate), WithDoc("high when the coin lands on b")),
 	}), nil
 }
 
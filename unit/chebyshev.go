@@ -25,13 +25,13 @@ func newChebyshev(io *IO, c Config) (*Unit, error) {
 	}
 
 	cheb := &chebyshev{
-		in:     io.NewIn("in", dsp.Float64(0)),
+		in:     io.NewIn("in", dsp.Float64(0), WithRange(-1, 1), WithDoc("signal to shape")),
 		coeffs: make([]*In, config.Size),
-		out:    io.NewOut("out"),
+		out:    io.NewOut("out", WithDoc("sum of the weighted polynomials")),
 	}
 
 	for i := range cheb.coeffs {
-		cheb.coeffs[i] = io.NewIn(string(alphaSeries[i]), dsp.Float64(0))
+		cheb.coeffs[i] = io.NewIn(string(alphaSeries[i]), dsp.Float64(0), WithDoc("level of the polynomial of this order"))
 	}
 
 	return NewUnit(io, cheb), nil
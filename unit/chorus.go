@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultChorusVoices = 3
+	maxChorusVoices     = 8
+	maxChorusDelayMS    = 50
+	chorusSwingMS       = 5 // sweep of each voice's delay at full depth
+)
+
+func newChorus(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Voices int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Voices == 0 {
+		config.Voices = defaultChorusVoices
+	} else if config.Voices < 1 || config.Voices > maxChorusVoices {
+		return nil, c.errorf("voices must be between 1 and %d, got %d", maxChorusVoices, config.Voices)
+	}
+
+	maxDelay := dsp.Duration(maxChorusDelayMS+chorusSwingMS, c.SampleRate).Float64()
+	return NewUnit(io, &chorus{
+		modulationIO: newModulationIO(io, c, 0.8),
+		delay:        io.NewIn("delay", dsp.Duration(15, c.SampleRate), WithRange(1, maxChorusDelayMS), WithDoc("delay of the voices at the center of their sweep")),
+		voices:       config.Voices,
+		aDelay:       newModulatedDelay(maxDelay),
+		bDelay:       newModulatedDelay(maxDelay),
+		swing:        dsp.Duration(chorusSwingMS, c.SampleRate).Float64(),
+		maxDelay:     dsp.Duration(maxChorusDelayMS, c.SampleRate).Float64(),
+	}), nil
+}
+
+// chorus thickens its input with copies of it whose delays are swept by an LFO. Each voice's sweep is offset evenly
+// around the LFO's cycle, so the voices drift in and out of tune with one another.
+type chorus struct {
+	modulationIO
+	delay *In
+
+	voices          int
+	aDelay, bDelay  modulatedDelay
+	aLast, bLast    float64
+	phase           float64
+	swing, maxDelay float64
+}
+
+func (c *chorus) ProcessSample(i int) {
+	var (
+		s     = c.read(i)
+		delay = dsp.Clamp(c.delay.Read(i), 1, c.maxDelay)
+		swing = s.depth * c.swing
+		a, b  float64
+	)
+	c.aDelay.write(s.in + c.aLast*s.feedback)
+	c.bDelay.write(s.in + c.bLast*s.feedback)
+	for v := 0; v < c.voices; v++ {
+		phase := c.phase + twoPi*float64(v)/float64(c.voices)
+		a += c.aDelay.read(delay + swing*s.lfo(phase))
+		b += c.bDelay.read(delay + swing*s.lfo(phase+s.offset))
+	}
+	a /= float64(c.voices)
+	b /= float64(c.voices)
+	c.aLast, c.bLast = a, b
+	advanceLFO(&c.phase, math.Max(c.rate.Read(i), 0))
+
+	c.write(i, s, a, b)
+}
@@ -6,10 +6,10 @@ import (
 
 func newClip(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &clip{
-		in:    io.NewIn("in", dsp.Float64(0)),
-		level: io.NewIn("level", dsp.Float64(1)),
-		soft:  io.NewIn("soft", dsp.Float64(1)),
-		out:   io.NewOut("out"),
+		in:    io.NewIn("in", dsp.Float64(0), WithDoc("signal to clip")),
+		level: io.NewIn("level", dsp.Float64(1), WithDoc("clipping threshold")),
+		soft:  io.NewIn("soft", dsp.Float64(1), WithMeasure(MeasureMode), WithDoc("soft clipping when 1; hard clipping otherwise")),
+		out:   io.NewOut("out", WithDoc("clipped signal")),
 	}), nil
 }
 
@@ -6,11 +6,11 @@ import (
 
 func newClock(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &clock{
-		tempo:      io.NewIn("tempo", dsp.Frequency(1, c.SampleRate)),
-		pw:         io.NewIn("pulse-width", dsp.Float64(0.1)),
-		shuffle:    io.NewIn("shuffle", dsp.Float64(0)),
-		run:        io.NewIn("run", dsp.Float64(1)),
-		out:        io.NewOut("out"),
+		tempo:      io.NewIn("tempo", dsp.Frequency(1, c.SampleRate), WithDoc("rate of the clock")),
+		pw:         io.NewIn("pulse-width", dsp.Float64(0.1), WithRange(0, 1), WithDoc("portion of each cycle the gate is high")),
+		shuffle:    io.NewIn("shuffle", dsp.Float64(0), WithRange(-0.5, 0.5), WithDoc("swing applied to every other pulse")),
+		run:        io.NewIn("run", dsp.Float64(1), WithMeasure(MeasureGate), WithDoc("clock runs while high")),
+		out:        io.NewOut("out", WithMeasure(MeasureGate), WithDoc("clock pulses")),
 		sampleRate: float64(c.SampleRate),
 	}), nil
 }
@@ -15,9 +15,9 @@ func newClockDiv(io *IO, c Config) (*Unit, error) {
 	}
 
 	cd := &clockDiv{
-		in:   io.NewIn("in", dsp.Float64(0)),
-		div:  io.NewIn("div", dsp.Float64(config.Div)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("clock to divide")),
+		div:  io.NewIn("div", dsp.Float64(config.Div), WithDoc("number of input pulses per output pulse")),
+		out:  io.NewOut("out", WithMeasure(MeasureGate), WithDoc("divided clock")),
 		last: -1,
 	}
 	return NewUnit(io, cd), nil
@@ -17,9 +17,9 @@ func newClockMult(io *IO, c Config) (*Unit, error) {
 	}
 
 	cm := &clockMult{
-		in:   io.NewIn("in", dsp.Float64(0)),
-		mult: io.NewIn("mult", dsp.Float64(config.Mult)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("clock to multiply")),
+		mult: io.NewIn("mult", dsp.Float64(config.Mult), WithDoc("number of output pulses per input pulse")),
+		out:  io.NewOut("out", WithMeasure(MeasureGate), WithDoc("multiplied clock")),
 	}
 	return NewUnit(io, cm), nil
 }
@@ -6,10 +6,10 @@ const clusterSize = 12
 
 func newCluster(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &cluster{
-		freq:     io.NewIn("freq", dsp.Frequency(440, c.SampleRate)),
-		interval: io.NewIn("interval", dsp.Float64(1.1)),
+		freq:     io.NewIn("freq", dsp.Frequency(440, c.SampleRate), WithDoc("frequency of the lowest oscillator")),
+		interval: io.NewIn("interval", dsp.Float64(1.1), WithDoc("frequency ratio between neighboring oscillators")),
 		phases:   make([]float64, clusterSize),
-		out:      io.NewOut("out"),
+		out:      io.NewOut("out", WithDoc("sum of the oscillators")),
 	}), nil
 }
 
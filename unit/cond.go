@@ -6,10 +6,10 @@ import (
 
 func newCond(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &cond{
-		cond: io.NewIn("cond", dsp.Float64(0)),
-		x:    io.NewIn("x", dsp.Float64(0)),
-		y:    io.NewIn("y", dsp.Float64(0)),
-		out:  io.NewOut("out"),
+		cond: io.NewIn("cond", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("selects x when high and y when low")),
+		x:    io.NewIn("x", dsp.Float64(0), WithDoc("value passed while cond is high")),
+		y:    io.NewIn("y", dsp.Float64(0), WithDoc("value passed while cond is low")),
+		out:  io.NewOut("out", WithDoc("selected value")),
 	}), nil
 }
 
@@ -0,0 +1,219 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/brettbuddin/shaden/errors"
+)
+
+// Config is a map that's used to provide configuration options to Builders.
+type Config struct {
+	Values                map[string]any
+	Rand                  *rand.Rand
+	SampleRate, FrameSize int
+
+	// ControlPeriod is the number of samples between reads of slowly changing inputs. Zero uses DefaultControlPeriod.
+	ControlPeriod int
+
+	// Buffers are the named Buffers that Units can play back from instead of loading a file of their own.
+	Buffers *Buffers
+
+	// Describing is set when a Unit is built only to be described. It's closed without ever running, so Builders
+	// shouldn't open devices or start goroutines for it.
+	Describing bool
+
+	// typ is the type of Unit being built; used to identify the Unit in errors.
+	typ string
+	// decoded records whether the Builder asked for its configuration to be decoded. Units that never decode accept no
+	// configuration at all.
+	decoded *bool
+}
+
+// Decode loads a struct with the contents of the raw Config object. Keys that don't correspond to a field of the struct
+// and values that can't be represented by the field's type (e.g. a fractional number for an int) are errors.
+func (c Config) Decode(v any) error {
+	if c.decoded != nil {
+		*c.decoded = true
+	}
+
+	accepted := configKeys(v)
+	var keys []string
+	for k := range c.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !containsFold(accepted, k) {
+			return c.unknownKeyError(k, accepted)
+		}
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  integralHook,
+		ErrorUnused: true,
+		Result:      v,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(c.Values); err != nil {
+		if merr, ok := err.(*mapstructure.Error); ok {
+			return c.errorf("invalid config: %s", strings.Join(merr.Errors, "; "))
+		}
+		return c.errorf("invalid config: %s", err)
+	}
+	return nil
+}
+
+// loadBuffer returns the audio a Unit plays back from: either a named Buffer or a file that's loaded for the Unit
+// alone. Exactly one of the two must be specified.
+func (c Config) loadBuffer(file, name string) (*Buffer, error) {
+	var (
+		b   *Buffer
+		err error
+	)
+	switch {
+	case file != "" && name != "":
+		return nil, c.errorf("only one of file or buffer can be specified")
+	case name != "":
+		var ok bool
+		if b, ok = c.Buffers.Get(name); !ok {
+			return nil, c.errorf("unknown buffer %q", name)
+		}
+	case file != "":
+		if b, err = LoadBuffer(file); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, c.errorf("no file or buffer specified")
+	}
+	if b.Frames() == 0 {
+		return nil, c.errorf("%q contains no samples", firstNonEmpty(name, file))
+	}
+	return b, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// takeRate removes the "rate" key from the configuration values. It's accepted by the Units that can be processed at
+// control rate, which are only known once built. The values map is copied rather than modified.
+func (c *Config) takeRate() (Rate, bool, error) {
+	v, ok := c.Values["rate"]
+	if !ok {
+		return RateAudio, false, nil
+	}
+	values := make(map[string]any, len(c.Values)-1)
+	for k, v := range c.Values {
+		if k != "rate" {
+			values[k] = v
+		}
+	}
+	c.Values = values
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int:
+		return Rate(rv.Int()), true, nil
+	case reflect.Float64:
+		if f := rv.Float(); f == math.Trunc(f) {
+			return Rate(f), true, nil
+		}
+	case reflect.String:
+		// Names may be given as strings or as types based on them, such as keywords.
+		switch rv.String() {
+		case "audio":
+			return RateAudio, true, nil
+		case "control":
+			return RateControl, true, nil
+		}
+	}
+	return RateAudio, false, c.errorf("invalid rate %v (expected audio or control)", v)
+}
+
+func (c Config) unknownKeyError(key string, accepted []string) error {
+	if len(accepted) == 0 {
+		return c.errorf("unknown config key %q (accepts no config)", key)
+	}
+	return c.errorf("unknown config key %q (accepted keys: %s)", key, strings.Join(accepted, ", "))
+}
+
+func (c Config) errorf(format string, args ...any) error {
+	if c.typ == "" {
+		return errors.Errorf(format, args...)
+	}
+	return errors.Errorf("unit/"+c.typ+": "+format, args...)
+}
+
+// configKeys returns the sorted keys accepted when decoding into v; the lowercase field names of the struct, or the
+// names given by their mapstructure tags.
+func configKeys(v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.ToLower(f.Name)
+		if tag := strings.Split(f.Tag.Get("mapstructure"), ",")[0]; tag != "" {
+			name = tag
+		}
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// integralHook rejects fractional numbers destined for integer fields, which mapstructure would otherwise truncate.
+func integralHook(from, to reflect.Type, data any) (any, error) {
+	switch to.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		return data, nil
+	}
+	switch from.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := reflect.ValueOf(data).Float()
+		if f != math.Trunc(f) {
+			return nil, errors.Errorf("expected an integer, got %v", data)
+		}
+		return int(f), nil
+	}
+	return data, nil
+}
+
+func containsFold(keys []string, k string) bool {
+	for _, key := range keys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstKey(m map[string]any) string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
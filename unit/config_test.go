@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+func TestConfigDecode(t *testing.T) {
+	var config struct {
+		Size       int
+		SmoothTime dsp.MS
+	}
+	c := Config{Values: map[string]any{"size": 4.0, "smoothtime": dsp.Duration(10, sampleRate)}}
+	require.NoError(t, c.Decode(&config))
+	require.Equal(t, 4, config.Size)
+	require.Equal(t, 10.0, config.SmoothTime.Raw)
+}
+
+func TestConfigDecode_UnknownKey(t *testing.T) {
+	builder := Builders()["mix"]
+	_, err := builder(Config{
+		Values:     map[string]any{"sise": 4},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/mix: unknown config key "sise" (accepted keys: size)`)
+}
+
+func TestConfigDecode_InvalidType(t *testing.T) {
+	builder := Builders()["mix"]
+	_, err := builder(Config{
+		Values:     map[string]any{"size": "four"},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/mix: invalid config: 'Size' expected type 'int', got unconvertible type 'string'`)
+
+	_, err = builder(Config{
+		Values:     map[string]any{"size": 2.5},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/mix: invalid config: error decoding 'Size': expected an integer, got 2.5`)
+}
+
+func TestConfigDecode_NoConfig(t *testing.T) {
+	builder := Builders()["latch"]
+	_, err := builder(Config{
+		Values:     map[string]any{"size": 4},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/latch: unknown config key "size" (accepts no config)`)
+}
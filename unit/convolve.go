@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultConvolveBlock = 128
+	maxPreDelayMS        = 500
+)
+
+func newConvolve(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		File    string
+		Buffer  string
+		Block   int
+		Uniform bool
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Block == 0 {
+		config.Block = defaultConvolveBlock
+	} else if !dsp.IsPowerOfTwo(config.Block) {
+		return nil, c.errorf("block must be a power of two, got %d", config.Block)
+	}
+
+	buf, err := c.loadBuffer(config.File, config.Buffer)
+	if err != nil {
+		return nil, err
+	}
+	left, right := impulseResponses(buf, c.SampleRate)
+
+	maxPreDelay := dsp.Duration(maxPreDelayMS, c.SampleRate).Float64()
+	return NewUnit(io, &convolve{
+		a:           io.NewIn("a", dsp.Float64(0), WithDoc("left input")),
+		b:           io.NewIn("b", dsp.Float64(0), WithDoc("right input")),
+		mix:         io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between dry (-1) and wet (1)")),
+		predelay:    io.NewIn("predelay", dsp.Duration(0, c.SampleRate), WithRange(0, maxPreDelayMS), WithDoc("delay before the impulse response")),
+		aOut:        io.NewOut("a", WithDoc("left output")),
+		bOut:        io.NewOut("b", WithDoc("right output")),
+		aConv:       dsp.NewConvolver(left, config.Block, config.Uniform),
+		bConv:       dsp.NewConvolver(right, config.Block, config.Uniform),
+		aDL:         dsp.NewDelayLine(int(maxPreDelay) + 2),
+		bDL:         dsp.NewDelayLine(int(maxPreDelay) + 2),
+		maxPreDelay: maxPreDelay,
+	}), nil
+}
+
+// impulseResponses returns the left and right channels of an impulse response, resampled to the sample rate. A mono
+// response is used for both channels. They're scaled together so that the louder one has unit energy, which keeps the
+// level of broadband signals roughly the same whatever the length of the response.
+func impulseResponses(buf *Buffer, sampleRate int) (left, right []float64) {
+	var (
+		ph     = playhead{data: buf.Data, channels: buf.Channels}
+		ratio  = sampleRateRatio(buf.SampleRate, sampleRate)
+		frames = int(math.Ceil(float64(buf.Frames()) / ratio))
+	)
+	channel := func(c int) []float64 {
+		ir := make([]float64, frames)
+		for j := range ir {
+			if ratio == 1 {
+				ir[j] = buf.Data[j*buf.Channels+c]
+			} else {
+				ir[j] = ph.at(float64(j)*ratio, c)
+			}
+		}
+		return ir
+	}
+	left = channel(0)
+	right = left
+	if buf.Channels > 1 {
+		right = channel(1)
+	}
+
+	var energy float64
+	for _, ir := range [][]float64{left, right} {
+		var e float64
+		for _, v := range ir {
+			e += v * v
+		}
+		energy = math.Max(energy, e)
+	}
+	if energy > 0 {
+		scale := 1 / math.Sqrt(energy)
+		if buf.Channels > 1 {
+			for j := range right {
+				right[j] *= scale
+			}
+		}
+		for j := range left {
+			left[j] *= scale
+		}
+	}
+	return left, right
+}
+
+// convolve is a convolution reverb: each channel of the input is convolved with the matching channel of an impulse
+// response, such as a recording of a room or a speaker cabinet.
+type convolve struct {
+	a, b, mix, predelay *In
+	aOut, bOut          *Out
+	aConv, bConv        *dsp.Convolver
+	aDL, bDL            *dsp.DelayLine
+	maxPreDelay         float64
+}
+
+func (c *convolve) ProcessSample(i int) {
+	var (
+		a        = c.a.Read(i)
+		b        = c.b.Read(i)
+		mix      = c.mix.Read(i)
+		predelay = dsp.Clamp(c.predelay.Read(i), 0, c.maxPreDelay)
+	)
+
+	// Reading before writing puts the sample written n samples ago at position n. Pre-delays shorter than a sample
+	// are interpolated from the input itself.
+	aWet, bWet := a, b
+	switch {
+	case predelay >= 1:
+		aWet, bWet = c.aDL.ReadAbsolute(predelay), c.bDL.ReadAbsolute(predelay)
+	case predelay > 0:
+		aWet, bWet = dsp.Lerp(a, c.aDL.ReadAbsolute(1), predelay), dsp.Lerp(b, c.bDL.ReadAbsolute(1), predelay)
+	}
+	c.aDL.Write(a)
+	c.bDL.Write(b)
+
+	c.aOut.Write(i, dsp.Mix(mix, a, c.aConv.Tick(aWet)))
+	c.bOut.Write(i, dsp.Mix(mix, b, c.bConv.Tick(bWet)))
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// convolveImpulse feeds an impulse to both inputs and returns n samples of each output.
+func convolveImpulse(u *Unit, inputs map[string]float64, n int) (a, b []float64) {
+	for len(a) < n {
+		for i := 0; i < frameSize; i++ {
+			var v float64
+			if len(a) == 0 && i == 0 {
+				v = 1
+			}
+			u.In["a"].Write(i, v)
+			u.In["b"].Write(i, v)
+			for name, value := range inputs {
+				u.In[name].Write(i, value)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			a = append(a, u.Out["a"].Out().Read(i))
+			b = append(b, u.Out["b"].Out().Read(i))
+		}
+	}
+	return a[:n], b[:n]
+}
+
+func TestConvolve(t *testing.T) {
+	// A stereo response with an echo 1000 frames in on the left and 3000 frames in on the right.
+	ir := make([]float64, 2*4000)
+	ir[0], ir[1] = 0.5, 0.5
+	ir[2*1000] = 0.25
+	ir[2*3000+1] = -0.25
+	path := writeTestWAV(t, sampleRate, 2, ir)
+
+	for _, uniform := range []bool{true, false} {
+		u := newTestUnit(t, "convolve", map[string]any{"file": path, "uniform": uniform})
+		a, b := convolveImpulse(u, map[string]float64{"mix": 1}, 4000)
+
+		// Normalized to unit energy
+		scale := 1 / math.Sqrt(0.5*0.5+0.25*0.25)
+		for j := range a {
+			var expectA, expectB float64
+			switch j {
+			case 0:
+				expectA, expectB = 0.5*scale, 0.5*scale
+			case 1000:
+				expectA = 0.25 * scale
+			case 3000:
+				expectB = -0.25 * scale
+			}
+			require.InDelta(t, expectA, a[j], 1e-4, "uniform %v, frame %d", uniform, j)
+			require.InDelta(t, expectB, b[j], 1e-4, "uniform %v, frame %d", uniform, j)
+		}
+	}
+}
+
+func TestConvolve_PreDelayAndMix(t *testing.T) {
+	path := writeTestWAV(t, sampleRate, 1, []float64{1})
+
+	u := newTestUnit(t, "convolve", map[string]any{"file": path})
+	a, b := convolveImpulse(u, map[string]float64{"mix": 1, "predelay": 100}, 200)
+	for j := range a {
+		expected := 0.0
+		if j == 100 {
+			expected = 1
+		}
+		require.InDelta(t, expected, a[j], 1e-6, "frame %d", j)
+		require.InDelta(t, expected, b[j], 1e-6, "frame %d", j)
+	}
+
+	u = newTestUnit(t, "convolve", map[string]any{"file": path})
+	a, _ = convolveImpulse(u, map[string]float64{"mix": -1, "predelay": 100}, 200)
+	require.InDelta(t, 1, a[0], 1e-6)
+	require.InDelta(t, 0, a[100], 1e-6)
+}
+
+func TestConvolve_InvalidConfig(t *testing.T) {
+	path := writeTestWAV(t, sampleRate, 1, []float64{1})
+	_, err := Builders()["convolve"](Config{Values: map[string]any{"file": path, "block": 100}})
+	require.EqualError(t, err, "unit/convolve: block must be a power of two, got 100")
+
+	_, err = Builders()["convolve"](Config{})
+	require.EqualError(t, err, "unit/convolve: no file or buffer specified")
+}
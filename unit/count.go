@@ -6,13 +6,13 @@ import (
 
 func newCount(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &count{
-		trigger:   io.NewIn("trigger", dsp.Float64(-1)),
-		reset:     io.NewIn("reset", dsp.Float64(-1)),
-		limit:     io.NewIn("limit", dsp.Float64(32)),
-		step:      io.NewIn("step", dsp.Float64(1)),
-		offset:    io.NewIn("offset", dsp.Float64(0)),
-		out:       io.NewOut("out"),
-		resetOut:  io.NewOut("reset"),
+		trigger:   io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("advances the count")),
+		reset:     io.NewIn("reset", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("resets the count to zero")),
+		limit:     io.NewIn("limit", dsp.Float64(32), WithMeasure(MeasureInteger), WithDoc("count wraps around at this value")),
+		step:      io.NewIn("step", dsp.Float64(1), WithDoc("amount added to the count per trigger")),
+		offset:    io.NewIn("offset", dsp.Float64(0), WithMeasure(MeasureInteger), WithDoc("amount added to the output")),
+		out:       io.NewOut("out", WithDoc("current count plus offset")),
+		resetOut:  io.NewOut("reset", WithMeasure(MeasureGate), WithDoc("high when the count wraps or is reset")),
 		lastReset: -1,
 	}), nil
 }
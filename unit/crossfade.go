@@ -4,10 +4,10 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newCrossfade(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &crossfade{
-		a:   io.NewIn("a", dsp.Float64(0)),
-		b:   io.NewIn("b", dsp.Float64(0)),
-		mix: io.NewIn("mix", dsp.Float64(0)),
-		out: io.NewOut("out"),
+		a:   io.NewIn("a", dsp.Float64(0), WithDoc("first signal")),
+		b:   io.NewIn("b", dsp.Float64(0), WithDoc("second signal")),
+		mix: io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between a (-1) and b (1)")),
+		out: io.NewOut("out", WithDoc("mixed signal")),
 	}), nil
 }
 
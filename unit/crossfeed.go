@@ -4,11 +4,11 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newCrossfeed(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &crossfeed{
-		a:      io.NewIn("a", dsp.Float64(0)),
-		b:      io.NewIn("b", dsp.Float64(0)),
-		amount: io.NewIn("amount", dsp.Float64(0)),
-		aOut:   io.NewOut("a"),
-		bOut:   io.NewOut("b"),
+		a:      io.NewIn("a", dsp.Float64(0), WithDoc("first signal")),
+		b:      io.NewIn("b", dsp.Float64(0), WithDoc("second signal")),
+		amount: io.NewIn("amount", dsp.Float64(0), WithRange(0, 1), WithDoc("amount of each signal fed into the other")),
+		aOut:   io.NewOut("a", WithDoc("a with b fed in")),
+		bOut:   io.NewOut("b", WithDoc("b with a fed in")),
 	}), nil
 }
 
@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// envelopeStage is a segment of a dahdsrEnvelope.
+type envelopeStage int
+
+const (
+	envelopeIdle envelopeStage = iota
+	envelopeDelay
+	envelopeAttack
+	envelopeHold
+	envelopeDecay
+	envelopeSustain
+	envelopeRelease
+)
+
+// envelopeFloor is the distance from its target at which a decay or release is considered to have finished (-60dB).
+const envelopeFloor = 0.001
+
+// dahdsr are the settings of a dahdsrEnvelope. Durations are in samples and the sustain level is in the range [0, 1].
+type dahdsr struct {
+	delay, attack, hold, decay, sustain, release float64
+}
+
+// dahdsrEnvelope is a DAHDSR envelope with a linear attack and exponential decay and release. Its settings are given
+// on every tick so they can be modulated; the decay and release coefficients are only recalculated when their
+// durations change.
+type dahdsrEnvelope struct {
+	stage                  envelopeStage
+	level, elapsed, from   float64
+	decayDur, releaseDur   float64
+	decayCoef, releaseCoef float64
+}
+
+// trigger starts the envelope from its delay. The attack rises from wherever a retrigger left the envelope.
+func (e *dahdsrEnvelope) trigger() {
+	e.advance(envelopeDelay)
+}
+
+// release moves the envelope to its release, unless it has already finished.
+func (e *dahdsrEnvelope) release() {
+	if e.stage != envelopeIdle {
+		e.advance(envelopeRelease)
+	}
+}
+
+// done returns whether the envelope has finished its release, or hasn't been triggered.
+func (e *dahdsrEnvelope) done() bool { return e.stage == envelopeIdle }
+
+// tick returns the envelope's current level and advances it.
+func (e *dahdsrEnvelope) tick(s dahdsr) float64 {
+	sustain := dsp.Clamp(s.sustain, 0, 1)
+
+	// Segments with no duration are passed through within the same sample.
+	for {
+		switch e.stage {
+		case envelopeDelay:
+			if e.elapsed < s.delay {
+				e.elapsed++
+				return e.level
+			}
+			e.from = e.level
+			e.advance(envelopeAttack)
+			continue
+		case envelopeAttack:
+			if e.elapsed < s.attack {
+				e.elapsed++
+				e.level = e.from + (1-e.from)*math.Min(e.elapsed/s.attack, 1)
+				return e.level
+			}
+			e.level = 1
+			e.advance(envelopeHold)
+			continue
+		case envelopeHold:
+			if e.elapsed < s.hold {
+				e.elapsed++
+				return e.level
+			}
+			e.advance(envelopeDecay)
+			continue
+		case envelopeDecay:
+			if s.decay != e.decayDur {
+				e.decayDur, e.decayCoef = s.decay, envelopeCoef(s.decay)
+			}
+			e.level = sustain + (e.level-sustain)*e.decayCoef
+			if e.level-sustain < envelopeFloor {
+				e.level = sustain
+				e.advance(envelopeSustain)
+			}
+			return e.level
+		case envelopeSustain:
+			e.level = sustain
+			return e.level
+		case envelopeRelease:
+			if s.release != e.releaseDur {
+				e.releaseDur, e.releaseCoef = s.release, envelopeCoef(s.release)
+			}
+			e.level *= e.releaseCoef
+			if e.level < envelopeFloor {
+				e.level = 0
+				e.advance(envelopeIdle)
+			}
+			return e.level
+		}
+		e.level = 0
+		return 0
+	}
+}
+
+func (e *dahdsrEnvelope) advance(stage envelopeStage) {
+	e.stage = stage
+	e.elapsed = 0
+}
+
+// envelopeCoef returns the per-sample coefficient of an exponential segment that reaches envelopeFloor in a duration
+// of samples.
+func envelopeCoef(duration float64) float64 {
+	if duration < 1 {
+		return 0
+	}
+	return math.Pow(envelopeFloor, 1/duration)
+}
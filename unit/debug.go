@@ -11,10 +11,10 @@ func newDebug(io *IO, c Config) (*Unit, error) {
 		fmt: io.NewProp("fmt", "%.8f", func(p *Prop, v any) error {
 			p.value = v
 			return nil
-		}),
-		in:         io.NewIn("in", dsp.Float64(0)),
-		rate:       io.NewIn("rate", dsp.Float64(0.1)),
-		out:        io.NewOut("out"),
+		}, WithDoc("format used when printing values")),
+		in:         io.NewIn("in", dsp.Float64(0), WithDoc("signal to print")),
+		rate:       io.NewIn("rate", dsp.Float64(0.1), WithRange(0.01, 1), WithDoc("seconds between prints")),
+		out:        io.NewOut("out", WithDoc("input passed through")),
 		sampleRate: c.SampleRate,
 	}), nil
 }
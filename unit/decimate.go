@@ -7,10 +7,10 @@ func newDecimate(io *IO, c Config) (*Unit, error) {
 		decimate: &dsp.Decimate{
 			SampleRate: float64(c.SampleRate),
 		},
-		in:   io.NewIn("in", dsp.Float64(0)),
-		rate: io.NewIn("rate", dsp.Float64(c.SampleRate)),
-		bits: io.NewIn("bits", dsp.Float64(24)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithDoc("signal to decimate")),
+		rate: io.NewIn("rate", dsp.Float64(c.SampleRate), WithDoc("sample rate to reduce to")),
+		bits: io.NewIn("bits", dsp.Float64(24), WithRange(1, 24), WithDoc("bit depth to reduce to")),
+		out:  io.NewOut("out", WithDoc("decimated signal")),
 	}), nil
 }
 
@@ -1,34 +1,85 @@
 package unit
 
 import (
+	"fmt"
+
 	"github.com/brettbuddin/shaden/dsp"
 )
 
-const maxDelayMS = 10000
+const (
+	maxDelayMS     = 10000
+	maxDelayTaps   = 8
+	maxDelaySlewMS = 2000
+)
+
+var delayInterpolations = map[string]dsp.Interpolation{
+	"":        dsp.InterpolateLinear,
+	"linear":  dsp.InterpolateLinear,
+	"cubic":   dsp.InterpolateCubic,
+	"allpass": dsp.InterpolateAllPass,
+}
 
 func newDelay(io *IO, c Config) (*Unit, error) {
-	maxDelay := dsp.Duration(maxDelayMS, c.SampleRate).Float64()
+	var config struct {
+		Interpolation string
+		Taps          int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
 
-	return NewUnit(io, &delay{
-		dl:       dsp.NewDelayLine(int(maxDelay)),
-		in:       io.NewIn("in", dsp.Float64(0)),
-		time:     io.NewIn("time", dsp.Duration(500, c.SampleRate)),
-		mix:      io.NewIn("mix", dsp.Float64(0)),
-		fbreturn: io.NewIn("fb-return", dsp.Float64(0)),
-		fbgain:   io.NewIn("fb-gain", dsp.Float64(0)),
-		out:      io.NewOut("out"),
-		fbsend:   io.NewOut("fb-send"),
+	interpolation, ok := delayInterpolations[config.Interpolation]
+	if !ok {
+		return nil, c.errorf("unknown interpolation %q; expected linear, cubic or allpass", config.Interpolation)
+	}
+	if config.Taps < 0 || config.Taps > maxDelayTaps {
+		return nil, c.errorf("taps must be between 0 and %d, got %d", maxDelayTaps, config.Taps)
+	}
+
+	maxDelay := dsp.Duration(maxDelayMS, c.SampleRate).Float64()
+	d := &delay{
+		// Room for the samples either side of the longest delay that interpolation reads
+		dl:       dsp.NewDelayLine(int(maxDelay) + 3),
+		in:       io.NewIn("in", dsp.Float64(0), WithDoc("signal to delay")),
+		time:     io.NewIn("time", dsp.Duration(500, c.SampleRate), WithRange(0, maxDelayMS), WithDoc("delay time")),
+		slew:     io.NewIn("slew", dsp.Duration(0, c.SampleRate), WithRange(0, maxDelaySlewMS), WithDoc("time taken to glide to a new delay time, bending pitch like tape")),
+		mix:      io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between dry (-1) and wet (1)")),
+		fbreturn: io.NewIn("fb-return", dsp.Float64(0), WithDoc("feedback returned from processing patched to fb-send")),
+		fbgain:   io.NewIn("fb-gain", dsp.Float64(0), WithRange(0, 1), WithDoc("amount of feedback")),
+		out:      io.NewOut("out", WithDoc("mix of the dry and delayed signal")),
+		fbsend:   io.NewOut("fb-send", WithDoc("delayed signal sent out for processing in the feedback path")),
 		block:    &dsp.DCBlock{},
+		tap:      dsp.DelayTap{Interpolation: interpolation},
 		maxDelay: maxDelay,
-	}), nil
+
+		taps:      make([]dsp.DelayTap, config.Taps),
+		tapTimes:  make([]*In, config.Taps),
+		tapLevels: make([]*In, config.Taps),
+		tapOuts:   make([]*Out, config.Taps),
+	}
+	for i := range d.taps {
+		// Taps are spread evenly across the default delay time.
+		time := 500 * float64(i+1) / float64(config.Taps+1)
+		d.taps[i].Interpolation = interpolation
+		d.tapTimes[i] = io.NewIn(fmt.Sprintf("%d/time", i), dsp.Duration(time, c.SampleRate), WithRange(0, maxDelayMS), WithDoc("delay time of the tap"))
+		d.tapLevels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1), WithDoc("level of the tap in the delayed signal"))
+		d.tapOuts[i] = io.NewOut(fmt.Sprintf("%d/out", i), WithDoc("signal read by the tap"))
+	}
+	return NewUnit(io, d), nil
 }
 
 type delay struct {
-	in, time, mix, fbreturn, fbgain *In
-	out, fbsend                     *Out
-	dl                              *dsp.DelayLine
-	maxDelay, last                  float64
-	block                           *dsp.DCBlock
+	in, time, slew, mix, fbreturn, fbgain *In
+	out, fbsend                           *Out
+	dl                                    *dsp.DelayLine
+	tap                                   dsp.DelayTap
+	maxDelay, last                        float64
+	block                                 *dsp.DCBlock
+
+	// Extra taps are read from the same delay line and mixed into the delayed signal, but aren't fed back.
+	taps                []dsp.DelayTap
+	tapTimes, tapLevels []*In
+	tapOuts             []*Out
 }
 
 func (d *delay) ProcessSample(i int) {
@@ -37,9 +88,12 @@ func (d *delay) ProcessSample(i int) {
 		mix    = d.mix.Read(i)
 		fbgain = d.fbgain.Read(i)
 		time   = dsp.Clamp(d.time.Read(i), 0, d.maxDelay)
+		slew   = d.slew.Read(i)
 	)
 
-	wet := d.dl.TickAbsolute(in+d.last*fbgain, time)
+	d.dl.Write(in + d.last*fbgain)
+	d.tap.Slew = slew
+	wet := d.tap.Read(d.dl, time)
 
 	if d.fbsend.DestinationCount() > 0 {
 		d.fbsend.Write(i, wet)
@@ -47,5 +101,13 @@ func (d *delay) ProcessSample(i int) {
 	} else {
 		d.last = wet
 	}
-	d.out.Write(i, d.block.Tick(dsp.Mix(mix, in, d.last)))
+
+	out := d.last
+	for j := range d.taps {
+		d.taps[j].Slew = slew
+		v := d.taps[j].Read(d.dl, dsp.Clamp(d.tapTimes[j].Read(i), 0, d.maxDelay))
+		d.tapOuts[j].Write(i, v)
+		out += v * d.tapLevels[j].Read(i)
+	}
+	d.out.Write(i, d.block.Tick(dsp.Mix(mix, in, out)))
 }
@@ -72,3 +72,84 @@ func TestDelay(t *testing.T) {
 		require.InEpsilon(t, e, sample[i], 1e-15, "sample %d", i)
 	}
 }
+
+func TestDelay_Taps(t *testing.T) {
+	u, err := Builders()["delay"](Config{
+		Values:     map[string]any{"taps": 2, "interpolation": "cubic"},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < frameSize; i++ {
+		var in float64
+		if i == 0 {
+			in = 1
+		}
+		u.In["in"].Write(i, in)
+		u.In["mix"].Write(i, 1)
+		u.In["time"].Write(i, 101)
+		u.In["0/time"].Write(i, 11)
+		u.In["1/time"].Write(i, 51)
+		u.In["1/level"].Write(i, 0.5)
+	}
+	u.ProcessFrame(frameSize)
+
+	var (
+		out  = u.Out["out"].Out()
+		tap0 = u.Out["0/out"].Out()
+		tap1 = u.Out["1/out"].Out()
+	)
+	// After a write, a delay of n+1 reads the sample from n samples ago.
+	require.InDelta(t, 1, tap0.Read(10), 1e-9)
+	require.InDelta(t, 1, tap1.Read(50), 1e-9)
+	require.InDelta(t, 0, tap1.Read(10), 1e-9)
+	require.InDelta(t, 1, out.Read(10), 1e-2)
+	require.InDelta(t, 0.5, out.Read(50), 1e-2)
+	require.InDelta(t, 1, out.Read(100), 1e-2)
+}
+
+func TestDelay_Slew(t *testing.T) {
+	u, err := Builders()["delay"](Config{
+		Values:     map[string]any{"taps": 1},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	// A ramp delayed by a time that glides from 10 to 20 samples slows down, then catches up to its old rate. The tap is
+	// measured, as the output has its DC blocked.
+	var outs []float64
+	for f := 0; f < 4; f++ {
+		for i := 0; i < frameSize; i++ {
+			n := f*frameSize + i
+			time := 10.0
+			if n >= 2*frameSize {
+				time = 20
+			}
+			u.In["in"].Write(i, float64(n))
+			u.In["0/time"].Write(i, time)
+			u.In["slew"].Write(i, 20)
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			outs = append(outs, u.Out["0/out"].Out().Read(i))
+		}
+	}
+	var (
+		before = outs[2*frameSize-1] - outs[2*frameSize-2]
+		during = outs[2*frameSize+10] - outs[2*frameSize+9]
+		after  = outs[4*frameSize-1] - outs[4*frameSize-2]
+	)
+	require.InDelta(t, 1, before, 1e-2)
+	require.True(t, during < 0.8)
+	require.InDelta(t, 1, after, 1e-2)
+}
+
+func TestDelay_InvalidConfig(t *testing.T) {
+	_, err := Builders()["delay"](Config{Values: map[string]any{"interpolation": "sinc"}})
+	require.EqualError(t, err, `unit/delay: unknown interpolation "sinc"; expected linear, cubic or allpass`)
+
+	_, err = Builders()["delay"](Config{Values: map[string]any{"taps": 9}})
+	require.EqualError(t, err, "unit/delay: taps must be between 0 and 8, got 9")
+}
@@ -22,12 +22,12 @@ func newDemux(io *IO, c Config) (*Unit, error) {
 		outs = make([]*Out, config.Size)
 	)
 	for i := 0; i < config.Size; i++ {
-		outs[i] = io.NewOut(fmt.Sprintf("%d", i))
+		outs[i] = io.NewOut(fmt.Sprintf("%d", i), WithDoc("receives the input while selected"))
 	}
 
 	return NewUnit(io, &demux{
-		in:        io.NewIn("in", dsp.Float64(0)),
-		selection: io.NewIn("select", dsp.Float64(1)),
+		in:        io.NewIn("in", dsp.Float64(0), WithDoc("signal to route")),
+		selection: io.NewIn("select", dsp.Float64(1), WithMeasure(MeasureInteger), WithDoc("index of the output receiving the signal")),
 		outs:      outs,
 	}), nil
 }
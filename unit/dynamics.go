@@ -10,14 +10,14 @@ var log1 = math.Log(0.1)
 
 func newDynamics(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &dynamics{
-		in:        io.NewIn("in", dsp.Float64(0)),
-		control:   io.NewIn("control", dsp.Float64(0)),
-		threshold: io.NewIn("threshold", dsp.Float64(0.5)),
-		above:     io.NewIn("above", dsp.Float64(0.3)),
-		below:     io.NewIn("below", dsp.Float64(1)),
-		clamp:     io.NewIn("clamp", dsp.Duration(10, c.SampleRate)),
-		relax:     io.NewIn("relax", dsp.Duration(10, c.SampleRate)),
-		out:       io.NewOut("out"),
+		in:        io.NewIn("in", dsp.Float64(0), WithDoc("signal to process")),
+		control:   io.NewIn("control", dsp.Float64(0), WithDoc("signal whose level drives the gain")),
+		threshold: io.NewIn("threshold", dsp.Float64(0.5), WithRange(0, 1), WithDoc("level where the slope changes")),
+		above:     io.NewIn("above", dsp.Float64(0.3), WithDoc("slope above the threshold; less than 1 compresses")),
+		below:     io.NewIn("below", dsp.Float64(1), WithDoc("slope below the threshold; greater than 1 gates")),
+		clamp:     io.NewIn("clamp", dsp.Duration(10, c.SampleRate), WithDoc("time taken to react to rising levels")),
+		relax:     io.NewIn("relax", dsp.Duration(10, c.SampleRate), WithDoc("time taken to react to falling levels")),
+		out:       io.NewOut("out", WithDoc("processed signal")),
 		dcBlock:   &dsp.DCBlock{},
 		lastClamp: -1,
 		lastRelax: -1,
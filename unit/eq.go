@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultEQBands = 4
+	maxEQBands     = 16
+	eqLowest       = 100.0  // Hz
+	eqHighest      = 8000.0 // Hz
+)
+
+func newEQ(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Bands int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Bands == 0 {
+		config.Bands = defaultEQBands
+	} else if config.Bands < 1 || config.Bands > maxEQBands {
+		return nil, c.errorf("bands must be between 1 and %d, got %d", maxEQBands, config.Bands)
+	}
+
+	e := &eq{
+		in:      io.NewIn("in", dsp.Float64(0), WithDoc("signal to equalize")),
+		out:     io.NewOut("out", WithDoc("equalized signal")),
+		filters: make([]dsp.Biquad, config.Bands),
+		types:   make([]*In, config.Bands),
+		freqs:   make([]*In, config.Bands),
+		gains:   make([]*In, config.Bands),
+		qs:      make([]*In, config.Bands),
+	}
+	for i := range e.filters {
+		// Bands are spread evenly in pitch, with shelves at either end.
+		var (
+			freq = eqLowest
+			typ  = dsp.Peak
+		)
+		if config.Bands > 1 {
+			freq = eqLowest * math.Pow(eqHighest/eqLowest, float64(i)/float64(config.Bands-1))
+			switch i {
+			case 0:
+				typ = dsp.LowShelf
+			case config.Bands - 1:
+				typ = dsp.HighShelf
+			}
+		}
+		e.types[i] = io.NewIn(fmt.Sprintf("%d/type", i), dsp.Float64(float64(typ)), WithMeasure(MeasureMode), WithRange(0, 5), WithDoc("peak, low shelf, high shelf, notch, low cut or high cut"))
+		e.freqs[i] = io.NewIn(fmt.Sprintf("%d/freq", i), dsp.Frequency(freq, c.SampleRate), WithRange(0, float64(c.SampleRate)/2), WithDoc("center or corner frequency of the band"))
+		e.gains[i] = io.NewIn(fmt.Sprintf("%d/gain", i), dsp.Float64(1), WithDoc("gain of peaks and shelves"))
+		e.qs[i] = io.NewIn(fmt.Sprintf("%d/q", i), dsp.Float64(math.Sqrt2/2), WithDoc("width of peaks and notches, slope of shelves and resonance of cuts"))
+	}
+
+	return NewUnit(io, e), nil
+}
+
+// eq is a parametric equalizer. Its bands are biquad filters applied one after another.
+type eq struct {
+	in                      *In
+	out                     *Out
+	filters                 []dsp.Biquad
+	types, freqs, gains, qs []*In
+}
+
+func (e *eq) ProcessSample(i int) {
+	v := e.in.Read(i)
+	for j := range e.filters {
+		f := &e.filters[j]
+		f.Type = dsp.BiquadType(e.types[j].ReadSlowInt(i, clampInt(0, 5)))
+		f.Cutoff = e.freqs[j].Read(i)
+		f.Gain = e.gains[j].Read(i)
+		f.Q = e.qs[j].Read(i)
+		v = f.Tick(v)
+	}
+	e.out.Write(i, v)
+}
@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// eqGain measures the gain of the unit at a frequency from its response to a sine.
+func eqGain(u *Unit, freq float64) float64 {
+	var peak float64
+	for n := 0; n < 100; n++ {
+		for i := 0; i < frameSize; i++ {
+			u.In["in"].Write(i, math.Sin(2*math.Pi*freq*float64(n*frameSize+i)))
+		}
+		u.ProcessFrame(frameSize)
+		if n > 50 {
+			for i := 0; i < frameSize; i++ {
+				peak = math.Max(peak, math.Abs(u.Out["out"].Out().Read(i)))
+			}
+		}
+	}
+	return peak
+}
+
+func TestEQ(t *testing.T) {
+	u, err := Builders()["eq"](Config{
+		Values:     map[string]any{"bands": 3},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	var (
+		low  = dsp.Frequency(30, sampleRate).Float64()
+		mid  = dsp.Frequency(1000, sampleRate).Float64()
+		high = dsp.Frequency(15000, sampleRate).Float64()
+	)
+
+	// Flat until a band is given some gain.
+	require.InDelta(t, 1, eqGain(u, mid), 0.01)
+
+	for i := 0; i < frameSize; i++ {
+		u.In["0/gain"].Write(i, 2)
+		u.In["1/freq"].Write(i, mid)
+		u.In["1/type"].Write(i, float64(dsp.Notch))
+		u.In["2/type"].Write(i, float64(dsp.HighCut))
+		u.In["2/freq"].Write(i, dsp.Frequency(5000, sampleRate).Float64())
+	}
+	require.InDelta(t, 2, eqGain(u, low), 0.05)
+	require.InDelta(t, 0, eqGain(u, mid), 0.05)
+	require.InDelta(t, 0, eqGain(u, high), 0.1)
+}
+
+func TestEQ_InvalidBands(t *testing.T) {
+	_, err := Builders()["eq"](Config{
+		Values:     map[string]any{"bands": 20},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/eq: bands must be between 1 and 16, got 20")
+}
@@ -8,16 +8,16 @@ const maxEuclidLayers = 256
 
 func newEuclid(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &euclid{
-		clock:       io.NewIn("clock", dsp.Float64(-1)),
-		span:        io.NewIn("span", dsp.Float64(5)),
-		fill:        io.NewIn("fill", dsp.Float64(2)),
-		offset:      io.NewIn("offset", dsp.Float64(0)),
-		reset:       io.NewIn("reset", dsp.Float64(-1)),
+		clock:       io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("advances the pattern")),
+		span:        io.NewIn("span", dsp.Float64(5), WithMeasure(MeasureInteger), WithDoc("number of steps in the pattern")),
+		fill:        io.NewIn("fill", dsp.Float64(2), WithMeasure(MeasureInteger), WithDoc("number of steps that are active")),
+		offset:      io.NewIn("offset", dsp.Float64(0), WithMeasure(MeasureInteger), WithDoc("rotation of the pattern")),
+		reset:       io.NewIn("reset", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("returns to the first step")),
 		counts:      make([]int, maxEuclidLayers),
 		remainders:  make([]int, maxEuclidLayers),
 		pattern:     make([]bool, maxEuclidLayers),
 		lastTrigger: -1,
-		out:         io.NewOut("out"),
+		out:         io.NewOut("out", WithMeasure(MeasureGate), WithDoc("high on active steps")),
 	}), nil
 }
 
@@ -4,32 +4,60 @@ import (
 	"github.com/brettbuddin/shaden/dsp"
 )
 
+var filterModels = map[string]dsp.ZDFModel{
+	"ladder": dsp.Ladder,
+	"diode":  dsp.DiodeLadder,
+	"ms20":   dsp.SallenKey,
+}
+
 func newFilter(io *IO, c Config) (*Unit, error) {
 	var config struct {
-		Poles int
+		Poles *int
+		Model string
 	}
 	if err := c.Decode(&config); err != nil {
 		return nil, err
 	}
 
-	if config.Poles == 0 {
-		config.Poles = 4
-	} else if config.Poles > 8 {
-		config.Poles = 8
+	if config.Model != "" && config.Model != "svf" {
+		model, ok := filterModels[config.Model]
+		if !ok {
+			return nil, c.errorf("unknown model %q; expected svf, ladder, diode or ms20", config.Model)
+		}
+		if config.Poles != nil {
+			return nil, c.errorf("poles only applies to the svf model, not %s", config.Model)
+		}
+		return newModelFilter(io, c, model), nil
+	}
+
+	poles, err := filterPoles(c, config.Poles)
+	if err != nil {
+		return nil, err
 	}
 
 	return NewUnit(io, &filter{
-		filter: &dsp.SVFilter{Poles: config.Poles},
-		in:     io.NewIn("in", dsp.Float64(0)),
-		cutoff: io.NewIn("cutoff", dsp.Frequency(1000, c.SampleRate)),
-		res:    io.NewIn("res", dsp.Float64(1)),
-		poles:  io.NewIn("poles", dsp.Float64(config.Poles)),
-		lp:     io.NewOut("lp"),
-		bp:     io.NewOut("bp"),
-		hp:     io.NewOut("hp"),
+		filter: &dsp.SVFilter{Poles: poles},
+		in:     io.NewIn("in", dsp.Float64(0), WithDoc("signal to filter")),
+		cutoff: io.NewIn("cutoff", dsp.Frequency(1000, c.SampleRate), WithRange(0, float64(c.SampleRate)/2), WithDoc("cutoff frequency")),
+		res:    io.NewIn("res", dsp.Float64(1), WithDoc("resonance; values below 1 have no effect")),
+		poles:  io.NewIn("poles", dsp.Float64(poles), WithRange(1, 8), WithDoc("number of stages the signal passes through")),
+		lp:     io.NewOut("lp", WithDoc("lowpass response")),
+		bp:     io.NewOut("bp", WithDoc("bandpass response")),
+		hp:     io.NewOut("hp", WithDoc("highpass response")),
 	}), nil
 }
 
+// filterPoles returns the number of poles configured for a state-variable filter, or 4 when it isn't configured.
+func filterPoles(c Config, poles *int) (int, error) {
+	if poles == nil {
+		return 4, nil
+	}
+	if *poles < 1 || *poles > 8 {
+		return 0, c.errorf("poles must be between 1 and 8, got %d", *poles)
+	}
+	return *poles, nil
+}
+
 type filter struct {
 	in, cutoff, res, poles *In
 	lp, bp, hp             *Out
@@ -45,3 +73,34 @@ func (f *filter) ProcessSample(i int) {
 	f.bp.Write(i, bp)
 	f.hp.Write(i, hp)
 }
+
+func newModelFilter(io *IO, c Config, model dsp.ZDFModel) *Unit {
+	return NewUnit(io, &modelFilter{
+		filter: &dsp.ZDFFilter{Model: model},
+		in:     io.NewIn("in", dsp.Float64(0), WithDoc("signal to filter")),
+		cutoff: io.NewIn("cutoff", dsp.Frequency(1000, c.SampleRate), WithRange(0, float64(c.SampleRate)/2), WithDoc("cutoff frequency, where the filter resonates")),
+		res:    io.NewIn("res", dsp.Float64(0), WithRange(0, 1), WithDoc("resonance; the filter oscillates on its own close to 1")),
+		drive:  io.NewIn("drive", dsp.Float64(1), WithDoc("how hard the filter saturates")),
+		lp:     io.NewOut("lp", WithDoc("lowpass response")),
+		bp:     io.NewOut("bp", WithDoc("bandpass response")),
+		hp:     io.NewOut("hp", WithDoc("highpass response")),
+	})
+}
+
+// modelFilter is a filter modelled on an analog circuit: a Moog-style transistor ladder, a TB-303-style diode ladder
+// or an MS-20-style Sallen-Key filter.
+type modelFilter struct {
+	in, cutoff, res, drive *In
+	lp, bp, hp             *Out
+	filter                 *dsp.ZDFFilter
+}
+
+func (f *modelFilter) ProcessSample(i int) {
+	f.filter.Cutoff = f.cutoff.Read(i)
+	f.filter.Resonance = f.res.Read(i)
+	f.filter.Drive = f.drive.Read(i)
+	lp, bp, hp := f.filter.Tick(f.in.Read(i))
+	f.lp.Write(i, lp)
+	f.bp.Write(i, bp)
+	f.hp.Write(i, hp)
+}
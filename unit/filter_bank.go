@@ -36,19 +36,19 @@ func newFilterBank(io *IO, c Config) (*Unit, error) {
 			Poles:     4,
 			Resonance: res,
 		}
-		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1))
-		cutoffs[i] = io.NewIn(fmt.Sprintf("%d/cutoff", i), cutoff)
-		resonances[i] = io.NewIn(fmt.Sprintf("%d/res", i), dsp.Float64(res))
+		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1), WithDoc("level of the band"))
+		cutoffs[i] = io.NewIn(fmt.Sprintf("%d/cutoff", i), cutoff, WithDoc("center frequency of the band"))
+		resonances[i] = io.NewIn(fmt.Sprintf("%d/res", i), dsp.Float64(res), WithDoc("resonance of the band"))
 		freq += 600.0
 	}
 
 	return NewUnit(io, &filterBank{
 		filters:    filters,
-		in:         io.NewIn("in", dsp.Float64(0)),
+		in:         io.NewIn("in", dsp.Float64(0), WithDoc("signal to filter")),
 		levels:     levels,
 		cutoffs:    cutoffs,
 		resonances: resonances,
-		out:        io.NewOut("out"),
+		out:        io.NewOut("out", WithDoc("average of the bands")),
 	}), nil
 }
 
@@ -31,3 +31,90 @@ func TestFilter(t *testing.T) {
 	require.Equal(t, 0.9841277044498926, hp.Read(0))
 	require.Equal(t, 1.0, lp.Read(0)+bp.Read(0)+hp.Read(0))
 }
+
+func TestFilter_InvalidPoles(t *testing.T) {
+	builder := Builders()["filter"]
+	_, err := builder(Config{
+		Values:     map[string]any{"poles": 12},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/filter: poles must be between 1 and 8, got 12")
+
+	_, err = builder(Config{
+		Values:     map[string]any{"poles": 0},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/filter: poles must be between 1 and 8, got 0")
+
+	_, err = builder(Config{
+		Values:     map[string]any{"poles": 2.5},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.Error(t, err)
+}
+
+func TestFilter_Models(t *testing.T) {
+	for _, model := range []string{"ladder", "diode", "ms20"} {
+		u, err := Builders()["filter"](Config{
+			Values:     map[string]any{"model": model},
+			SampleRate: sampleRate,
+			FrameSize:  frameSize,
+		})
+		require.NoError(t, err)
+		require.NotContains(t, u.In, "poles")
+
+		// A constant passes through the lowpass; a signal at Nyquist passes through the highpass.
+		var lp, hp float64
+		for n := 0; n < 20; n++ {
+			for i := 0; i < frameSize; i++ {
+				u.In["cutoff"].Write(i, dsp.Frequency(2000, sampleRate).Float64())
+				u.In["in"].Write(i, 0.001+0.001*float64(1-2*(i%2)))
+			}
+			u.ProcessFrame(frameSize)
+			lp = u.Out["lp"].Out().Read(frameSize - 1)
+			hp = u.Out["hp"].Out().Read(frameSize-1) - u.Out["hp"].Out().Read(frameSize-2)
+		}
+		require.InDelta(t, 0.001, lp, 1e-5, model)
+		require.InDelta(t, -0.002, hp, 1e-4, model)
+	}
+}
+
+func TestFilter_InvalidModel(t *testing.T) {
+	_, err := Builders()["filter"](Config{
+		Values:     map[string]any{"model": "wasp"},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/filter: unknown model "wasp"; expected svf, ladder, diode or ms20`)
+}
+
+func TestFilter_ModelPoles(t *testing.T) {
+	_, err := Builders()["filter"](Config{
+		Values:     map[string]any{"model": "ladder", "poles": 2},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/filter: poles only applies to the svf model, not ladder")
+
+	_, err = Builders()["filter"](Config{
+		Values:     map[string]any{"model": "svf", "poles": 2},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+}
+
+func TestFilter_CutoffRange(t *testing.T) {
+	for _, model := range []string{"svf", "ladder"} {
+		u, err := Builders()["filter"](Config{
+			Values:     map[string]any{"model": model},
+			SampleRate: 48000,
+			FrameSize:  frameSize,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 24000.0, u.In["cutoff"].Meta().Max)
+	}
+}
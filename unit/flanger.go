@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	maxFlangerDelayMS = 20
+	flangerSwingMS    = 5 // sweep of the delay at full depth
+)
+
+func newFlanger(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		ThroughZero bool
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	var (
+		swing    = dsp.Duration(flangerSwingMS, c.SampleRate).Float64()
+		maxDelay = dsp.Duration(maxFlangerDelayMS, c.SampleRate).Float64()
+	)
+	return NewUnit(io, &flanger{
+		modulationIO: newModulationIO(io, c, 0.2),
+		delay:        io.NewIn("delay", dsp.Duration(1, c.SampleRate), WithRange(0, maxFlangerDelayMS), WithDoc("shortest delay of the sweep; unused when sweeping through zero")),
+		throughZero:  config.ThroughZero,
+		aDelay:       newModulatedDelay(maxDelay + 2*swing),
+		bDelay:       newModulatedDelay(maxDelay + 2*swing),
+		dryDelay:     newModulatedDelay(swing),
+		swing:        swing,
+		maxDelay:     maxDelay,
+	}), nil
+}
+
+// flanger mixes its input with a copy of it whose delay is swept by an LFO, which sweeps a series of notches through
+// its spectrum.
+//
+// When sweeping through zero, the dry signal is also delayed, by the middle of the sweep, so the swept copy passes from
+// behind the dry signal to ahead of it. Where they meet, every frequency is briefly in phase, like two tape machines
+// flanged by hand.
+type flanger struct {
+	modulationIO
+	delay *In
+
+	throughZero     bool
+	aDelay, bDelay  modulatedDelay
+	dryDelay        modulatedDelay
+	aLast, bLast    float64
+	phase           float64
+	swing, maxDelay float64
+}
+
+func (f *flanger) ProcessSample(i int) {
+	var (
+		s     = f.read(i)
+		swing = s.depth * f.swing
+		low   = dsp.Clamp(f.delay.Read(i), 0, f.maxDelay)
+	)
+	if f.throughZero {
+		// The sweep is centered on the dry signal's delay, which is fixed so that depth doesn't shift it.
+		low = f.swing - swing
+	}
+	var (
+		aDelay = low + swing*(1+s.lfo(f.phase))
+		bDelay = low + swing*(1+s.lfo(f.phase+s.offset))
+	)
+
+	f.aDelay.write(s.in + f.aLast*s.feedback)
+	f.bDelay.write(s.in + f.bLast*s.feedback)
+	a, b := f.aDelay.read(aDelay), f.bDelay.read(bDelay)
+	f.aLast, f.bLast = a, b
+	advanceLFO(&f.phase, math.Max(f.rate.Read(i), 0))
+
+	if f.throughZero {
+		f.dryDelay.write(s.in)
+		s.in = f.dryDelay.read(f.swing)
+	}
+	f.write(i, s, a, b)
+}
@@ -0,0 +1,215 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// fmModIndex is the phase deviation, in radians, that a modulating operator at full level applies to the operators it
+// modulates.
+const fmModIndex = 2 * math.Pi
+
+// fmAlgorithm routes the operators of an FM voice. Operators are numbered from 1 and always modulate lower-numbered
+// operators, so a voice is computed from its highest operator down.
+type fmAlgorithm struct {
+	links    [][2]int // modulator, modulated
+	carriers []int
+}
+
+// fmAlgorithms4 are the algorithms of 4-operator voices, following the DX9/TX81Z charts.
+var fmAlgorithms4 = []fmAlgorithm{
+	{links: [][2]int{{4, 3}, {3, 2}, {2, 1}}, carriers: []int{1}},
+	{links: [][2]int{{4, 2}, {3, 2}, {2, 1}}, carriers: []int{1}},
+	{links: [][2]int{{3, 2}, {2, 1}, {4, 1}}, carriers: []int{1}},
+	{links: [][2]int{{4, 3}, {3, 1}, {2, 1}}, carriers: []int{1}},
+	{links: [][2]int{{2, 1}, {4, 3}}, carriers: []int{1, 3}},
+	{links: [][2]int{{4, 1}, {4, 2}, {4, 3}}, carriers: []int{1, 2, 3}},
+	{links: [][2]int{{4, 3}}, carriers: []int{1, 2, 3}},
+	{carriers: []int{1, 2, 3, 4}},
+}
+
+// fmAlgorithms6 are the algorithms of 6-operator voices, following the DX7 charts. Feedback is set per operator rather
+// than fixed by the algorithm, so algorithms that differ only in where their feedback sits share a routing.
+var fmAlgorithms6 = []fmAlgorithm{
+	{links: [][2]int{{2, 1}, {6, 5}, {5, 4}, {4, 3}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {6, 5}, {5, 4}, {4, 3}}, carriers: []int{1, 3}},
+	{links: [][2]int{{3, 2}, {2, 1}, {6, 5}, {5, 4}}, carriers: []int{1, 4}},
+	{links: [][2]int{{3, 2}, {2, 1}, {6, 5}, {5, 4}}, carriers: []int{1, 4}},
+	{links: [][2]int{{2, 1}, {4, 3}, {6, 5}}, carriers: []int{1, 3, 5}},
+	{links: [][2]int{{2, 1}, {4, 3}, {6, 5}}, carriers: []int{1, 3, 5}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 3}, {6, 5}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 3}, {6, 5}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 3}, {6, 5}}, carriers: []int{1, 3}},
+	{links: [][2]int{{3, 2}, {2, 1}, {5, 4}, {6, 4}}, carriers: []int{1, 4}},
+	{links: [][2]int{{3, 2}, {2, 1}, {5, 4}, {6, 4}}, carriers: []int{1, 4}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 3}, {6, 3}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 3}, {6, 3}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 4}, {6, 4}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 4}, {6, 4}}, carriers: []int{1, 3}},
+	{links: [][2]int{{2, 1}, {3, 1}, {4, 3}, {5, 1}, {6, 5}}, carriers: []int{1}},
+	{links: [][2]int{{2, 1}, {3, 1}, {4, 3}, {5, 1}, {6, 5}}, carriers: []int{1}},
+	{links: [][2]int{{2, 1}, {3, 1}, {4, 1}, {5, 4}, {6, 5}}, carriers: []int{1}},
+	{links: [][2]int{{2, 1}, {3, 2}, {6, 4}, {6, 5}}, carriers: []int{1, 4, 5}},
+	{links: [][2]int{{3, 1}, {3, 2}, {5, 4}, {6, 4}}, carriers: []int{1, 2, 4}},
+	{links: [][2]int{{3, 1}, {3, 2}, {6, 4}, {6, 5}}, carriers: []int{1, 2, 4, 5}},
+	{links: [][2]int{{2, 1}, {6, 3}, {6, 4}, {6, 5}}, carriers: []int{1, 3, 4, 5}},
+	{links: [][2]int{{3, 2}, {6, 4}, {6, 5}}, carriers: []int{1, 2, 4, 5}},
+	{links: [][2]int{{6, 3}, {6, 4}, {6, 5}}, carriers: []int{1, 2, 3, 4, 5}},
+	{links: [][2]int{{6, 4}, {6, 5}}, carriers: []int{1, 2, 3, 4, 5}},
+	{links: [][2]int{{3, 2}, {5, 4}, {6, 4}}, carriers: []int{1, 2, 4}},
+	{links: [][2]int{{3, 2}, {5, 4}, {6, 4}}, carriers: []int{1, 2, 4}},
+	{links: [][2]int{{2, 1}, {4, 3}, {5, 4}}, carriers: []int{1, 3, 6}},
+	{links: [][2]int{{4, 3}, {6, 5}}, carriers: []int{1, 2, 3, 5}},
+	{links: [][2]int{{4, 3}, {5, 4}}, carriers: []int{1, 2, 3, 6}},
+	{links: [][2]int{{6, 5}}, carriers: []int{1, 2, 3, 4, 5}},
+	{carriers: []int{1, 2, 3, 4, 5, 6}},
+}
+
+func newFM(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Operators int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	var algorithms []fmAlgorithm
+	switch config.Operators {
+	case 0, 4:
+		config.Operators = 4
+		algorithms = fmAlgorithms4
+	case 6:
+		algorithms = fmAlgorithms6
+	default:
+		return nil, c.errorf("operators must be 4 or 6; got %d", config.Operators)
+	}
+
+	f := &fm{
+		freq:      io.NewIn("freq", dsp.Frequency(440, c.SampleRate), WithDoc("frequency that operator ratios are relative to")),
+		gate:      io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("starts the operator envelopes when going high; releases them when going low")),
+		algorithm: io.NewIn("algorithm", dsp.Float64(1), WithMeasure(MeasureMode), WithRange(1, float64(len(algorithms))), WithDoc("routing of the operators, numbered as on the DX9 (4 operators) or DX7 (6 operators)")),
+		out:       io.NewOut("out", WithDoc("average of the carriers")),
+		ops:       make([]fmOperator, config.Operators),
+		routes:    make([]fmRoute, len(algorithms)),
+		outputs:   make([]float64, config.Operators),
+		lastGate:  -1,
+	}
+	for i := range f.ops {
+		n := i + 1
+		f.ops[i] = fmOperator{
+			ratio:    io.NewIn(fmt.Sprintf("%d/ratio", n), dsp.Float64(1), WithDoc("frequency of the operator relative to freq")),
+			fixed:    io.NewIn(fmt.Sprintf("%d/fixed", n), dsp.Frequency(0, c.SampleRate), WithDoc("fixed frequency of the operator; overrides the ratio when above zero")),
+			level:    io.NewIn(fmt.Sprintf("%d/level", n), dsp.Float64(1), WithRange(0, 1), WithDoc("output level of the operator")),
+			feedback: io.NewIn(fmt.Sprintf("%d/feedback", n), dsp.Float64(0), WithRange(0, 1), WithDoc("amount the operator modulates itself")),
+			attack:   io.NewIn(fmt.Sprintf("%d/attack", n), dsp.Duration(1, c.SampleRate), WithDoc("duration of the envelope's attack")),
+			decay:    io.NewIn(fmt.Sprintf("%d/decay", n), dsp.Duration(500, c.SampleRate), WithDoc("duration of the envelope's decay")),
+			sustain:  io.NewIn(fmt.Sprintf("%d/sustain", n), dsp.Float64(1), WithRange(0, 1), WithDoc("level held by the envelope after the decay")),
+			release:  io.NewIn(fmt.Sprintf("%d/release", n), dsp.Duration(200, c.SampleRate), WithDoc("duration of the envelope's release")),
+		}
+	}
+	for j, a := range algorithms {
+		f.routes[j] = newFMRoute(a, config.Operators)
+	}
+
+	return NewUnit(io, f), nil
+}
+
+// fmRoute is an algorithm resolved to the zero-based indexes of each operator's modulators and of the carriers.
+type fmRoute struct {
+	modulators [][]int
+	carriers   []int
+}
+
+func newFMRoute(a fmAlgorithm, operators int) fmRoute {
+	r := fmRoute{modulators: make([][]int, operators)}
+	for _, l := range a.links {
+		r.modulators[l[1]-1] = append(r.modulators[l[1]-1], l[0]-1)
+	}
+	for _, c := range a.carriers {
+		r.carriers = append(r.carriers, c-1)
+	}
+	return r
+}
+
+// fm is a phase modulation synthesizer in the style of the DX7. Each operator is a sine oscillator with its own
+// envelope. Operator phases are reset when the gate goes high, so every note starts the same way.
+type fm struct {
+	freq, gate, algorithm *In
+	out                   *Out
+	ops                   []fmOperator
+	routes                []fmRoute
+	outputs               []float64
+	lastGate              float64
+}
+
+func (f *fm) ProcessSample(i int) {
+	var (
+		freq  = f.freq.Read(i)
+		gate  = f.gate.Read(i)
+		route = &f.routes[int(dsp.Clamp(math.Round(f.algorithm.Read(i)), 1, float64(len(f.routes))))-1]
+	)
+	switch {
+	case isTrig(f.lastGate, gate):
+		for j := range f.ops {
+			f.ops[j].trigger()
+		}
+	case f.lastGate > 0 && gate <= 0:
+		for j := range f.ops {
+			f.ops[j].env.release()
+		}
+	}
+	f.lastGate = gate
+
+	for j := len(f.ops) - 1; j >= 0; j-- {
+		var mod float64
+		for _, m := range route.modulators[j] {
+			mod += f.outputs[m]
+		}
+		f.outputs[j] = f.ops[j].tick(i, freq, mod*fmModIndex)
+	}
+
+	var out float64
+	for _, c := range route.carriers {
+		out += f.outputs[c]
+	}
+	f.out.Write(i, out/float64(len(route.carriers)))
+}
+
+type fmOperator struct {
+	ratio, fixed, level, feedback   *In
+	attack, decay, sustain, release *In
+	env                             dahdsrEnvelope
+	phase                           float64
+	last, prev                      float64
+}
+
+func (o *fmOperator) trigger() {
+	o.phase = 0
+	o.env.trigger()
+}
+
+// tick returns the operator's current output, with its phase offset by mod radians, and advances it.
+func (o *fmOperator) tick(i int, freq, mod float64) float64 {
+	env := o.env.tick(dahdsr{
+		attack:  o.attack.Read(i),
+		decay:   o.decay.Read(i),
+		sustain: o.sustain.Read(i),
+		release: o.release.Read(i),
+	})
+
+	// Feedback is taken from the average of the last two outputs, as on the DX7, which keeps high amounts from
+	// breaking into noise.
+	fb := o.feedback.Read(i) * math.Pi * (o.last + o.prev) / 2
+	out := dsp.Sin(twoPi*o.phase+mod+fb) * env * o.level.Read(i)
+	o.prev, o.last = o.last, out
+
+	if fixed := o.fixed.Read(i); fixed > 0 {
+		freq = fixed
+	} else {
+		freq *= o.ratio.Read(i)
+	}
+	o.phase = wrapPhase(o.phase + freq)
+	return out
+}
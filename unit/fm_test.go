@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFM writes a value to an input for the whole frame.
+func writeFM(u *Unit, name string, v float64) {
+	for i := 0; i < frameSize; i++ {
+		u.In[name].Write(i, v)
+	}
+}
+
+func TestFM_Carrier(t *testing.T) {
+	u := newTestUnit(t, "fm", map[string]any{"operators": 4})
+	writeFM(u, "freq", 0.01)
+	writeFM(u, "gate", 1)
+	writeFM(u, "1/attack", 0)
+	for _, name := range []string{"2/level", "3/level", "4/level"} {
+		writeFM(u, name, 0)
+	}
+	u.ProcessFrame(frameSize)
+
+	out := u.Out["out"].Out()
+	for i := 0; i < frameSize; i++ {
+		require.InDelta(t, math.Sin(2*math.Pi*0.01*float64(i)), out.Read(i), 1e-4)
+	}
+}
+
+func TestFM_Modulation(t *testing.T) {
+	u := newTestUnit(t, "fm", map[string]any{"operators": 4})
+	writeFM(u, "freq", 0.01)
+	writeFM(u, "gate", 1)
+	writeFM(u, "algorithm", 1)
+	writeFM(u, "2/ratio", 2)
+	writeFM(u, "2/level", 0.5)
+	writeFM(u, "1/attack", 0)
+	writeFM(u, "2/attack", 0)
+	writeFM(u, "3/level", 0)
+	writeFM(u, "4/level", 0)
+	u.ProcessFrame(frameSize)
+
+	out := u.Out["out"].Out()
+	for i := 0; i < frameSize; i++ {
+		var (
+			x   = float64(i)
+			mod = 0.5 * math.Sin(2*math.Pi*0.02*x)
+		)
+		require.InDelta(t, math.Sin(2*math.Pi*0.01*x+fmModIndex*mod), out.Read(i), 1e-3)
+	}
+
+	// The same operators as parallel carriers are averaged rather than modulating each other.
+	writeFM(u, "algorithm", 8)
+	writeFM(u, "gate", -1)
+	u.ProcessFrame(frameSize)
+	writeFM(u, "gate", 1)
+	u.ProcessFrame(frameSize)
+	for i := 0; i < frameSize; i++ {
+		x := float64(i)
+		expected := (math.Sin(2*math.Pi*0.01*x) + 0.5*math.Sin(2*math.Pi*0.02*x)) / 4
+		require.InDelta(t, expected, out.Read(i), 1e-3)
+	}
+}
+
+func TestFM_Fixed(t *testing.T) {
+	u := newTestUnit(t, "fm", map[string]any{"operators": 6})
+	writeFM(u, "freq", 0.01)
+	writeFM(u, "gate", 1)
+	writeFM(u, "algorithm", 32)
+	writeFM(u, "1/fixed", 0.05)
+	for n := 1; n <= 6; n++ {
+		writeFM(u, fmt.Sprintf("%d/attack", n), 0)
+	}
+	for _, name := range []string{"2/level", "3/level", "4/level", "5/level", "6/level"} {
+		writeFM(u, name, 0)
+	}
+	u.ProcessFrame(frameSize)
+
+	out := u.Out["out"].Out()
+	for i := 0; i < frameSize; i++ {
+		require.InDelta(t, math.Sin(2*math.Pi*0.05*float64(i))/6, out.Read(i), 1e-4)
+	}
+}
+
+func TestFM_Envelope(t *testing.T) {
+	u := newTestUnit(t, "fm", map[string]any{"operators": 4})
+	writeFM(u, "freq", 0.25)
+	writeFM(u, "algorithm", 8)
+	for n := 1; n <= 4; n++ {
+		writeFM(u, fmt.Sprintf("%d/attack", n), 10)
+		writeFM(u, fmt.Sprintf("%d/decay", n), 20)
+		writeFM(u, fmt.Sprintf("%d/sustain", n), 0.5)
+		writeFM(u, fmt.Sprintf("%d/release", n), 50)
+	}
+
+	// Silent until the gate goes high.
+	writeFM(u, "gate", -1)
+	u.ProcessFrame(frameSize)
+	out := u.Out["out"].Out()
+	for i := 0; i < frameSize; i++ {
+		require.Equal(t, 0.0, out.Read(i))
+	}
+
+	writeFM(u, "gate", 1)
+	u.ProcessFrame(frameSize)
+	var peak float64
+	for i := 0; i < frameSize; i++ {
+		peak = math.Max(peak, math.Abs(out.Read(i)))
+	}
+	require.InDelta(t, 1, peak, 0.01)
+	// A quarter-cycle sine peaks every four samples, so the last peak shows the sustain level.
+	require.InDelta(t, 0.5, math.Abs(out.Read(frameSize-3)), 0.01)
+
+	writeFM(u, "gate", -1)
+	u.ProcessFrame(frameSize)
+	require.Equal(t, 0.0, out.Read(frameSize-1))
+}
+
+func TestFM_InvalidConfig(t *testing.T) {
+	_, err := Builders()["fm"](Config{Values: map[string]any{"operators": 5}})
+	require.Error(t, err)
+	require.Equal(t, "unit/fm: operators must be 4 or 6; got 5", err.Error())
+}
@@ -6,10 +6,10 @@ import (
 
 func newFold(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &fold{
-		in:    io.NewIn("in", dsp.Float64(0)),
-		level: io.NewIn("level", dsp.Float64(0.8)),
-		gain:  io.NewIn("gain", dsp.Float64(1)),
-		out:   io.NewOut("out"),
+		in:    io.NewIn("in", dsp.Float64(0), WithDoc("signal to fold")),
+		level: io.NewIn("level", dsp.Float64(0.8), WithDoc("threshold the signal folds over")),
+		gain:  io.NewIn("gain", dsp.Float64(1), WithDoc("gain applied after folding")),
+		out:   io.NewOut("out", WithDoc("folded signal")),
 	}), nil
 }
 
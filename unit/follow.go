@@ -0,0 +1,88 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// Modes of follow
+const (
+	followPeak = iota
+	followRMS
+)
+
+// followRMSWindowMS is the time over which the power of a signal is averaged for its RMS level.
+const followRMSWindowMS = 20
+
+func newFollow(io *IO, c Config) (*Unit, error) {
+	return NewUnit(io, &follow{
+		in:      io.NewIn("in", dsp.Float64(0), WithDoc("signal to follow")),
+		attack:  io.NewIn("attack", dsp.Duration(10, c.SampleRate), WithRange(0, 1000), WithDoc("time taken to follow a rise in level")),
+		release: io.NewIn("release", dsp.Duration(100, c.SampleRate), WithRange(0, 5000), WithDoc("time taken to follow a fall in level")),
+		mode:    io.NewIn("mode", dsp.Float64(followPeak), WithMeasure(MeasureMode), WithRange(followPeak, followRMS), WithDoc("peak (0) or RMS (1) level")),
+		out:     io.NewOut("out", WithDoc("level of the signal")),
+		rmsCoef: followCoef(dsp.Duration(followRMSWindowMS, c.SampleRate).Float64()),
+	}), nil
+}
+
+// follow outputs the level of its input. The peak level follows the size of each sample, so it tracks transients
+// closely; the RMS level follows their power, averaged over a short window, which is closer to how loud the signal
+// sounds.
+type follow struct {
+	in, attack, release, mode *In
+	out                       *Out
+	follower                  envelopeFollower
+	power, rmsCoef            float64
+}
+
+func (f *follow) ProcessSample(i int) {
+	var (
+		in   = f.in.Read(i)
+		mode = f.mode.ReadSlowInt(i, clampInt(followPeak, followRMS))
+	)
+	f.follower.setTimes(f.attack.Read(i), f.release.Read(i))
+	if mode == followRMS {
+		f.power += (in*in - f.power) * f.rmsCoef
+		f.out.Write(i, f.follower.tick(math.Sqrt(f.power)))
+		return
+	}
+	f.out.Write(i, f.follower.tick(math.Abs(in)))
+}
+
+// envelopeFollower smooths a level with a one-pole filter that rises and falls at separate rates.
+type envelopeFollower struct {
+	value                   float64
+	lastAttack, lastRelease float64
+	attackCoef, releaseCoef float64
+}
+
+// setTimes sets the time, in samples, that the follower takes to rise and fall.
+func (f *envelopeFollower) setTimes(attack, release float64) {
+	if attack != f.lastAttack || f.attackCoef == 0 {
+		f.attackCoef = followCoef(attack)
+		f.lastAttack = attack
+	}
+	if release != f.lastRelease || f.releaseCoef == 0 {
+		f.releaseCoef = followCoef(release)
+		f.lastRelease = release
+	}
+}
+
+func (f *envelopeFollower) tick(level float64) float64 {
+	coef := f.releaseCoef
+	if level > f.value {
+		coef = f.attackCoef
+	}
+	f.value += (level - f.value) * coef
+	return f.value
+}
+
+// followCoef returns the coefficient of a one-pole filter that takes a number of samples to reach about two thirds of
+// the way to its target.
+func followCoef(samples float64) float64 {
+	if samples < 1 {
+		return 1
+	}
+	return 1 - math.Exp(-1/samples)
+}
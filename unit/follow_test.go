@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+func TestFollow(t *testing.T) {
+	level := func(mode float64, amp float64, frames int) float64 {
+		u, err := Builders()["follow"](Config{SampleRate: sampleRate, FrameSize: frameSize})
+		require.NoError(t, err)
+
+		freq := dsp.Frequency(440, sampleRate).Float64()
+		for f := 0; f < frames; f++ {
+			for i := 0; i < frameSize; i++ {
+				var in float64
+				if f < 50 {
+					in = amp * math.Sin(2*math.Pi*freq*float64(f*frameSize+i))
+				}
+				u.In["in"].Write(i, in)
+				u.In["mode"].Write(i, mode)
+				u.In["attack"].Write(i, dsp.Duration(5, sampleRate).Float64())
+				u.In["release"].Write(i, dsp.Duration(50, sampleRate).Float64())
+			}
+			u.ProcessFrame(frameSize)
+		}
+		return u.Out["out"].Out().Read(frameSize - 1)
+	}
+
+	// A steady sine's peak level rides just under its peaks, and its RMS level settles at a 1/√2 of them.
+	peak := level(followPeak, 0.5, 50)
+	require.True(t, peak > 0.4 && peak <= 0.5, "peak level %f", peak)
+	require.InDelta(t, 0.5/math.Sqrt2, level(followRMS, 0.5, 50), 0.02)
+
+	// Once the input stops, the level falls away.
+	require.True(t, level(followPeak, 0.5, 100) < 0.01)
+}
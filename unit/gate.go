@@ -4,27 +4,28 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newGate(io *IO, c Config) (*Unit, error) {
 	var config struct {
-		Poles int
+		Poles *int
 	}
 	if err := c.Decode(&config); err != nil {
 		return nil, err
 	}
 
-	if config.Poles == 0 {
-		config.Poles = 4
+	poles, err := filterPoles(c, config.Poles)
+	if err != nil {
+		return nil, err
 	}
 
 	return NewUnit(io, &gate{
-		filter:     &dsp.SVFilter{Poles: config.Poles},
-		in:         io.NewIn("in", dsp.Float64(0)),
-		control:    io.NewIn("control", dsp.Float64(1)),
-		mode:       io.NewIn("mode", dsp.Float64(gateModeCombo)),
-		cutoffhigh: io.NewIn("cutoff-high", dsp.Frequency(20000, c.SampleRate)),
-		cutofflow:  io.NewIn("cutoff-low", dsp.Frequency(0, c.SampleRate)),
-		resonance:  io.NewIn("res", dsp.Float64(1)),
-		aux:        io.NewIn("aux", dsp.Float64(0)),
-		out:        io.NewOut("out"),
-		sum:        io.NewOut("sum"),
+		filter:     &dsp.SVFilter{Poles: poles},
+		in:         io.NewIn("in", dsp.Float64(0), WithDoc("signal to gate")),
+		control:    io.NewIn("control", dsp.Float64(1), WithRange(0, 1), WithDoc("opens the gate")),
+		mode:       io.NewIn("mode", dsp.Float64(gateModeCombo), WithMeasure(MeasureMode), WithRange(0, 2), WithDoc("lowpass (0), lowpass and amplitude (1) or amplitude (2)")),
+		cutoffhigh: io.NewIn("cutoff-high", dsp.Frequency(20000, c.SampleRate), WithDoc("cutoff when fully open")),
+		cutofflow:  io.NewIn("cutoff-low", dsp.Frequency(0, c.SampleRate), WithDoc("cutoff when fully closed")),
+		resonance:  io.NewIn("res", dsp.Float64(1), WithDoc("filter resonance")),
+		aux:        io.NewIn("aux", dsp.Float64(0), WithDoc("signal added to the sum output")),
+		out:        io.NewOut("out", WithDoc("gated signal")),
+		sum:        io.NewOut("sum", WithDoc("gated signal plus aux")),
 	}), nil
 }
 
@@ -20,11 +20,11 @@ func newGateMix(io *IO, c Config) (*Unit, error) {
 
 	inputs := make([]*In, config.Size)
 	for i := 0; i < len(inputs); i++ {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(-1))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("gate to combine"))
 	}
 
 	return NewUnit(io, &gateMix{
-		out:    io.NewOut("out"),
+		out:    io.NewOut("out", WithMeasure(MeasureGate), WithDoc("high while any input is high")),
 		inputs: inputs,
 	}), nil
 }
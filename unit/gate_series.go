@@ -20,13 +20,13 @@ func newGateSeries(io *IO, c Config) (*Unit, error) {
 
 	outs := make([]*Out, config.Size)
 	for i := range outs {
-		outs[i] = io.NewOut(fmt.Sprintf("%d", i))
+		outs[i] = io.NewOut(fmt.Sprintf("%d", i), WithMeasure(MeasureGate), WithDoc("receives the clock while selected"))
 	}
 
 	return NewUnit(io, &gateSeries{
-		clock:   io.NewIn("clock", dsp.Float64(-1)),
-		advance: io.NewIn("advance", dsp.Float64(-1)),
-		reset:   io.NewIn("reset", dsp.Float64(-1)),
+		clock:   io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("clock routed to the selected output")),
+		advance: io.NewIn("advance", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("selects the next output; defaults to the clock")),
+		reset:   io.NewIn("reset", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("selects the first output")),
 		outs:    outs,
 		target:  -1,
 	}), nil
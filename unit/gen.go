@@ -12,26 +12,53 @@ const (
 	twoDivPi = 2 / math.Pi
 )
 
+// Quality modes of gen's waveforms. Fast waveforms (the default) are cheap and only partially band-limited. Band-limited
+// waveforms suppress aliasing, including under hard sync, at the cost of a sample of latency.
+const (
+	qualityBandLimited = "bandlimited"
+	qualityFast        = "fast"
+)
+
 func newGen(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Quality string
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
 	g := &gen{
 		rand:      c.Rand,
-		freq:      io.NewIn("freq", dsp.Frequency(440, c.SampleRate)),
-		amp:       io.NewIn("amp", dsp.Float64(1)),
-		fm:        io.NewIn("freq-mod", dsp.Float64(0)),
-		pw:        io.NewIn("pulse-width", dsp.Float64(1)),
-		pm:        io.NewIn("phase-mod", dsp.Float64(0)),
-		sync:      io.NewIn("sync", dsp.Float64(-1)),
-		offset:    io.NewIn("offset", dsp.Float64(0)),
+		freq:      io.NewIn("freq", dsp.Frequency(440, c.SampleRate), WithDoc("frequency of the oscillator")),
+		amp:       io.NewIn("amp", dsp.Float64(1), WithDoc("amplitude")),
+		fm:        io.NewIn("freq-mod", dsp.Float64(0), WithMeasure(MeasureHz), WithDoc("linear frequency modulation")),
+		pw:        io.NewIn("pulse-width", dsp.Float64(1), WithRange(0, 2), WithDoc("width of the pulse where 1 is square")),
+		pm:        io.NewIn("phase-mod", dsp.Float64(0), WithDoc("phase modulation in radians")),
+		sync:      io.NewIn("sync", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("resets the phase")),
+		offset:    io.NewIn("offset", dsp.Float64(0), WithDoc("amount added to the output")),
 		frameSize: c.FrameSize,
 	}
 
-	io.ExposeOutputProcessor(g.newSine("sine", 1))
-	io.ExposeOutputProcessor(g.newSine("sub-sine", 0.5))
-	io.ExposeOutputProcessor(g.newSaw("saw", 1))
-	io.ExposeOutputProcessor(g.newSaw("sub-saw", 0.5))
-	io.ExposeOutputProcessor(g.newTriangle())
-	io.ExposeOutputProcessor(g.newPulse("pulse", 1))
-	io.ExposeOutputProcessor(g.newPulse("sub-pulse", 0.5))
+	switch config.Quality {
+	case qualityBandLimited:
+		io.ExposeOutputProcessor(g.newBandLimited(shapeSine, "sine", 1, "sine wave"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapeSine, "sub-sine", 0.5, "sine wave an octave below"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapeSaw, "saw", 1, "sawtooth wave"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapeSaw, "sub-saw", 0.5, "sawtooth wave an octave below"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapeTriangle, "triangle", 1, "triangle wave"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapePulse, "pulse", 1, "pulse wave"))
+		io.ExposeOutputProcessor(g.newBandLimited(shapePulse, "sub-pulse", 0.5, "pulse wave an octave below"))
+	case "", qualityFast:
+		io.ExposeOutputProcessor(g.newSine("sine", 1, "sine wave"))
+		io.ExposeOutputProcessor(g.newSine("sub-sine", 0.5, "sine wave an octave below"))
+		io.ExposeOutputProcessor(g.newSaw("saw", 1, "sawtooth wave"))
+		io.ExposeOutputProcessor(g.newSaw("sub-saw", 0.5, "sawtooth wave an octave below"))
+		io.ExposeOutputProcessor(g.newTriangle())
+		io.ExposeOutputProcessor(g.newPulse("pulse", 1, "pulse wave"))
+		io.ExposeOutputProcessor(g.newPulse("sub-pulse", 0.5, "pulse wave an octave below"))
+	default:
+		return nil, c.errorf("unknown quality %q (expected %s or %s)", config.Quality, qualityBandLimited, qualityFast)
+	}
 	io.ExposeOutputProcessor(g.newNoise())
 	io.ExposeOutputProcessor(g.newCluster())
 
@@ -48,30 +75,30 @@ func (g *gen) newFrame() []float64 {
 	return make([]float64, g.frameSize)
 }
 
-func (g *gen) newSine(name string, mult float64) *genSine {
+func (g *gen) newSine(name string, mult float64, doc string) *genSine {
 	return &genSine{
 		gen:   g,
 		phase: g.rand.Float64() * twoPi,
 		mult:  mult,
-		out:   NewOut(name, g.newFrame()),
+		out:   NewOut(name, g.newFrame(), WithDoc(doc)),
 	}
 }
 
-func (g *gen) newSaw(name string, mult float64) *genSaw {
+func (g *gen) newSaw(name string, mult float64, doc string) *genSaw {
 	return &genSaw{
 		gen:   g,
 		phase: g.rand.Float64() * twoPi,
 		mult:  mult,
-		out:   NewOut(name, g.newFrame()),
+		out:   NewOut(name, g.newFrame(), WithDoc(doc)),
 	}
 }
 
-func (g *gen) newPulse(name string, mult float64) *genPulse {
+func (g *gen) newPulse(name string, mult float64, doc string) *genPulse {
 	return &genPulse{
 		gen:   g,
 		phase: g.rand.Float64() * twoPi,
 		mult:  mult,
-		out:   NewOut(name, g.newFrame()),
+		out:   NewOut(name, g.newFrame(), WithDoc(doc)),
 	}
 }
 
@@ -79,21 +106,21 @@ func (g *gen) newTriangle() *genTriangle {
 	return &genTriangle{
 		gen:   g,
 		phase: g.rand.Float64() * twoPi,
-		out:   NewOut("triangle", g.newFrame()),
+		out:   NewOut("triangle", g.newFrame(), WithDoc("triangle wave")),
 	}
 }
 
 func (g *gen) newNoise() *genNoise {
 	return &genNoise{
 		gen: g,
-		out: NewOut("noise", g.newFrame()),
+		out: NewOut("noise", g.newFrame(), WithDoc("white noise")),
 	}
 }
 
 func (g *gen) newCluster() *genCluster {
 	return &genCluster{
 		gen: g,
-		out: NewOut("cluster", g.newFrame()),
+		out: NewOut("cluster", g.newFrame(), WithDoc("sparse random impulses")),
 	}
 }
 
@@ -165,7 +192,7 @@ func (o *genSaw) ProcessSample(i int) {
 
 	p := (o.phase + pm) / twoPi
 	next = (2*p - 1)
-	next -= blep(p, freq, fm)
+	next -= dsp.PolyBLEP(p, math.Abs(freq+fm))
 	o.phase = stepPhase(freq, fm, o.phase, o.frameSize, o.frameSize)
 	o.out.Write(i, (amp*next)+offset)
 	o.lastSync = sync
@@ -209,8 +236,8 @@ func (o *genPulse) ProcessSample(i int) {
 		next = -1
 	}
 	p := (o.phase + pm) / twoPi
-	next += blep(p, freq, fm)
-	next -= blep(math.Mod(p+0.5, 1), freq, fm)
+	next += dsp.PolyBLEP(p, math.Abs(freq+fm))
+	next -= dsp.PolyBLEP(math.Mod(p+0.5, 1), math.Abs(freq+fm))
 
 	o.phase = stepPhase(freq, fm, o.phase, o.frameSize, o.frameSize)
 	o.out.Write(i, (amp*next)+offset)
@@ -253,8 +280,8 @@ func (o *genTriangle) ProcessSample(i int) {
 		next = -1
 	}
 	p := (o.phase + pm) / twoPi
-	next += blep(p, freq, fm)
-	next -= blep(math.Mod(p+0.5, 1), freq, fm)
+	next += dsp.PolyBLEP(p, math.Abs(freq+fm))
+	next -= dsp.PolyBLEP(math.Mod(p+0.5, 1), math.Abs(freq+fm))
 	next = freq*next + (1-freq)*o.last
 
 	o.phase = stepPhase(freq, fm, o.phase, o.frameSize, o.frameSize)
@@ -319,15 +346,3 @@ func stepPhase(freq, fm, phase float64, frameSize, n int) float64 {
 	}
 	return phase
 }
-
-func blep(p float64, freq, fm float64) float64 {
-	delta := math.Abs(freq + fm)
-	if p < delta {
-		p /= delta
-		return p + p - p*p - 1
-	} else if p > 1-delta {
-		p = (p - 1) / delta
-		return p + p + p*p + 1
-	}
-	return 0
-}
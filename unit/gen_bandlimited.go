@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+type waveShape int
+
+const (
+	shapeSine waveShape = iota
+	shapeSaw
+	shapePulse
+	shapeTriangle
+)
+
+// discontinuity is a jump in value (step) or slope (ramp) at a position in the waveform's normalized phase.
+type discontinuity struct {
+	pos, step, ramp float64
+}
+
+func (g *gen) newBandLimited(shape waveShape, name string, mult float64, doc string) *genBandLimited {
+	return &genBandLimited{
+		gen:   g,
+		shape: shape,
+		phase: g.rand.Float64(),
+		mult:  mult,
+		out:   NewOut(name, g.newFrame(), WithDoc(doc)),
+	}
+}
+
+// genBandLimited is a waveform of gen that corrects its discontinuities with PolyBLEP and PolyBLAMP residuals. The
+// phase is normalized [0, 1) and includes phase modulation, so modulation that moves the phase past a discontinuity is
+// corrected in the same way as the oscillator's own motion. Hard sync is treated as one more discontinuity, located
+// between samples by interpolating the sync signal's zero crossing.
+type genBandLimited struct {
+	*gen
+	shape                         waveShape
+	phase, mult, lastSync, lastPM float64
+	duty                          float64
+	blep                          dsp.BLEPBuffer
+	out                           *Out
+}
+
+func (o *genBandLimited) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
+func (o *genBandLimited) Out() *Out           { return o.out }
+
+func (o *genBandLimited) ProcessFrame(n int) {
+	for i := 0; i < n; i++ {
+		o.ProcessSample(i)
+	}
+}
+
+func (o *genBandLimited) ProcessSample(i int) {
+	var (
+		freq   = o.freq.Read(i) * o.mult
+		amp    = o.amp.Read(i)
+		fm     = o.fm.Read(i)
+		pm     = o.pm.Read(i)
+		offset = o.offset.Read(i)
+		sync   = o.sync.Read(i)
+	)
+	o.duty = dsp.Clamp(math.Abs(o.pw.Read(i))/2, 0, 1)
+
+	step := math.Min(math.Abs(freq+fm), 0.5) + (pm-o.lastPM)/twoPi
+	from, to := o.phase, o.phase+step
+
+	if o.lastSync < 0 && sync > 0 && step != 0 {
+		// Fraction of the way through the sample at which the sync signal crossed zero.
+		frac := o.lastSync / (o.lastSync - sync)
+		since := math.Min(1-frac, 0.999999)
+
+		edge := from + step*frac
+		o.correct(from, edge, to, step)
+
+		reset := wrapPhase(pm / twoPi)
+		edge = wrapPhase(edge)
+		o.blep.Step(since, o.value(reset)-o.value(edge))
+		o.blep.Ramp(since, (o.slope(reset)-o.slope(edge))*step)
+
+		to = reset + step*since
+		o.correct(reset, to, to, step)
+	} else {
+		o.correct(from, to, to, step)
+	}
+
+	o.phase = wrapPhase(to)
+	o.out.Write(i, amp*o.blep.Tick(o.value(o.phase))+offset)
+	o.lastSync = sync
+	o.lastPM = pm
+}
+
+// correct registers residuals for every discontinuity the phase passed through while moving from `from` to `to`. The
+// phases need not be wrapped. `ref` is the phase at the current sample, used to determine how long ago each
+// discontinuity happened.
+func (o *genBandLimited) correct(from, to, ref, step float64) {
+	if from == to {
+		return
+	}
+	dir := 1.0
+	lo, hi := from, to
+	if to < from {
+		dir = -1
+		lo, hi = to, from
+	}
+
+	for _, d := range o.discontinuities() {
+		for pos := math.Floor(lo-d.pos) + 1 + d.pos; pos <= hi; pos++ {
+			since := (ref - pos) / step
+			if since < 0 || since >= 1 {
+				continue
+			}
+			if d.step != 0 {
+				o.blep.Step(since, dir*d.step)
+			}
+			if d.ramp != 0 {
+				o.blep.Ramp(since, d.ramp*math.Abs(step))
+			}
+		}
+	}
+}
+
+// discontinuities returns the discontinuities of the waveform within a single cycle.
+func (o *genBandLimited) discontinuities() []discontinuity {
+	switch o.shape {
+	case shapeSaw:
+		return []discontinuity{{pos: 0, step: -2}}
+	case shapePulse:
+		if o.duty <= 0 || o.duty >= 1 {
+			return nil
+		}
+		return []discontinuity{{pos: 0, step: 2}, {pos: o.duty, step: -2}}
+	case shapeTriangle:
+		return []discontinuity{{pos: 0, ramp: 8}, {pos: 0.5, ramp: -8}}
+	}
+	return nil
+}
+
+// value returns the naive value of the waveform at a normalized phase.
+func (o *genBandLimited) value(t float64) float64 {
+	switch o.shape {
+	case shapeSaw:
+		return 2*t - 1
+	case shapePulse:
+		if t < o.duty {
+			return 1
+		}
+		return -1
+	case shapeTriangle:
+		if t < 0.5 {
+			return 4*t - 1
+		}
+		return 3 - 4*t
+	}
+	return math.Sin(twoPi * t)
+}
+
+// slope returns the rate of change of the waveform, per cycle, at a normalized phase.
+func (o *genBandLimited) slope(t float64) float64 {
+	switch o.shape {
+	case shapeSaw:
+		return 2
+	case shapeTriangle:
+		if t < 0.5 {
+			return 4
+		}
+		return -4
+	case shapeSine:
+		return twoPi * math.Cos(twoPi*t)
+	}
+	return 0
+}
+
+func wrapPhase(t float64) float64 {
+	return t - math.Floor(t)
+}
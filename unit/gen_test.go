@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,11 +22,13 @@ var _ = []genOutput{
 	&genTriangle{},
 	&genCluster{},
 	&genNoise{},
+	&genBandLimited{},
 }
 
 func TestGen_Sine(t *testing.T) {
 	builder := Builders()["gen"]
 	u, err := builder(Config{
+		Values:     map[string]any{"quality": "fast"},
 		Rand:       randtest.Static(),
 		SampleRate: sampleRate,
 		FrameSize:  frameSize,
@@ -47,6 +50,7 @@ func TestGen_Sine(t *testing.T) {
 func TestGen_Saw(t *testing.T) {
 	builder := Builders()["gen"]
 	u, err := builder(Config{
+		Values:     map[string]any{"quality": "fast"},
 		Rand:       randtest.Static(),
 		SampleRate: sampleRate,
 		FrameSize:  frameSize,
@@ -68,6 +72,7 @@ func TestGen_Saw(t *testing.T) {
 func TestGen_Pulse(t *testing.T) {
 	builder := Builders()["gen"]
 	u, err := builder(Config{
+		Values:     map[string]any{"quality": "fast"},
 		Rand:       randtest.Static(),
 		SampleRate: sampleRate,
 		FrameSize:  frameSize,
@@ -93,6 +98,7 @@ func TestGen_Pulse(t *testing.T) {
 func TestGen_Triangle(t *testing.T) {
 	builder := Builders()["gen"]
 	u, err := builder(Config{
+		Values:     map[string]any{"quality": "fast"},
 		Rand:       randtest.Static(),
 		SampleRate: sampleRate,
 		FrameSize:  frameSize,
@@ -112,3 +118,148 @@ func TestGen_Triangle(t *testing.T) {
 	require.NotEqual(t, 0.0, out.Out().Read(0))
 	require.NotEqual(t, 0.0, out.Out().Read(170))
 }
+
+func TestGen_DefaultQuality(t *testing.T) {
+	u, err := Builders()["gen"](Config{
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &genSaw{}, u.Out["saw"])
+	require.IsType(t, &genPulse{}, u.Out["pulse"])
+}
+
+func TestGen_InvalidQuality(t *testing.T) {
+	_, err := Builders()["gen"](Config{
+		Values:     map[string]any{"quality": "cheap"},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, `unit/gen: unknown quality "cheap" (expected bandlimited or fast)`)
+}
+
+func TestGen_BandLimited(t *testing.T) {
+	for _, name := range []string{"sine", "sub-sine", "saw", "sub-saw", "pulse", "sub-pulse", "triangle"} {
+		t.Run(name, func(t *testing.T) {
+			u, err := Builders()["gen"](Config{
+				Values:     map[string]any{"quality": "bandlimited"},
+				Rand:       randtest.Static(),
+				SampleRate: sampleRate,
+				FrameSize:  frameSize,
+			})
+			require.NoError(t, err)
+			out := u.Out[name].(*genBandLimited)
+
+			var peak float64
+			for i := 0; i < 4; i++ {
+				for j := 0; j < frameSize; j++ {
+					u.In["freq"].Write(j, dsp.Frequency(5000, sampleRate).Float64())
+					u.In["pulse-width"].Write(j, 0.7)
+					out.ProcessSample(j)
+					peak = math.Max(peak, math.Abs(out.Out().Read(j)))
+				}
+			}
+			require.True(t, peak > 0.5)
+			require.True(t, peak < 1.5)
+		})
+	}
+}
+
+func TestGen_BandLimitedReducesAliasing(t *testing.T) {
+	tests := []struct {
+		name, output string
+		pw, sync     float64
+	}{
+		{name: "pulse", output: "pulse", pw: 0.6},
+		{name: "triangle", output: "triangle", pw: 1},
+		{name: "synced saw", output: "saw", pw: 1, sync: 1130},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fast := genAliasing(t, test.output, "fast", test.pw, test.sync)
+			bandLimited := genAliasing(t, test.output, "bandlimited", test.pw, test.sync)
+			require.True(t, bandLimited < fast/2, "bandlimited %f, fast %f", bandLimited, fast)
+		})
+	}
+}
+
+func TestGen_BandLimitedSync(t *testing.T) {
+	u, err := Builders()["gen"](Config{
+		Values:     map[string]any{"quality": "bandlimited"},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	out := u.Out["saw"].(*genBandLimited)
+
+	freq := dsp.Frequency(100, sampleRate).Float64()
+	u.In["freq"].Write(0, freq)
+	u.In["freq"].Write(1, freq)
+	u.In["freq"].Write(2, freq)
+	u.In["sync"].Write(0, -1)
+	u.In["sync"].Write(1, 1)
+	u.In["sync"].Write(2, 1)
+	out.ProcessSample(0)
+	out.ProcessSample(1)
+	out.ProcessSample(2)
+
+	// The reset is smeared across the samples either side of the sync edge, then the saw restarts from the bottom.
+	require.True(t, out.Out().Read(1) > out.Out().Read(2))
+	require.True(t, out.Out().Read(2) < -0.5)
+}
+
+// genAliasing returns the fraction of a waveform's power that lies outside of its harmonics; all of it is fold-over
+// from harmonics above Nyquist. When synced, the waveform repeats at the sync frequency rather than its own.
+func genAliasing(t *testing.T, name, quality string, pw, syncFreq float64) float64 {
+	const (
+		freq = 3130.0
+		n    = 4410 // a whole number of cycles of freq and syncFreq
+	)
+	f0 := freq
+	if syncFreq > 0 {
+		f0 = syncFreq
+	}
+
+	u, err := Builders()["gen"](Config{
+		Values:     map[string]any{"quality": quality},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	out := u.Out[name].(OutputProcessor)
+
+	var samples []float64
+	for len(samples) < n+frameSize {
+		for j := 0; j < frameSize; j++ {
+			u.In["freq"].Write(j, dsp.Frequency(freq, sampleRate).Float64())
+			u.In["pulse-width"].Write(j, pw)
+			if syncFreq > 0 {
+				u.In["sync"].Write(j, math.Sin(2*math.Pi*syncFreq*float64(len(samples))/sampleRate))
+			}
+			out.ProcessSample(j)
+			samples = append(samples, out.Out().Read(j))
+		}
+	}
+	samples = samples[frameSize : frameSize+n]
+
+	var mean, total, harmonics float64
+	for _, v := range samples {
+		mean += v / n
+	}
+	for _, v := range samples {
+		total += (v - mean) * (v - mean)
+	}
+	for f := f0; f < sampleRate/2; f += f0 {
+		var re, im float64
+		for i, v := range samples {
+			re += (v - mean) * math.Cos(2*math.Pi*f*float64(i)/sampleRate)
+			im -= (v - mean) * math.Sin(2*math.Pi*f*float64(i)/sampleRate)
+		}
+		harmonics += 2 * (re*re + im*im) / n
+	}
+	return (total - harmonics) / total
+}
@@ -0,0 +1,197 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultGranularLength = 5000 // ms
+	defaultGranularGrains = 32
+)
+
+// Grain window shapes
+const (
+	grainWindowHann = iota
+	grainWindowTriangle
+	grainWindowTukey
+	grainWindowGaussian
+)
+
+var grainWindows = []func(float64) float64{
+	grainWindowHann:     dsp.Hann,
+	grainWindowTriangle: dsp.Triangle,
+	grainWindowTukey:    func(x float64) float64 { return dsp.Tukey(x, 0.5) },
+	grainWindowGaussian: func(x float64) float64 { return dsp.Gaussian(x, 0.4) },
+}
+
+func newGranular(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		File   string
+		Buffer string
+		Length dsp.MS
+		Grains int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Grains == 0 {
+		config.Grains = defaultGranularGrains
+	}
+	if config.Grains < 0 {
+		return nil, c.errorf("grains must be positive; got %d", config.Grains)
+	}
+
+	g := &granular{
+		rand:     c.Rand,
+		size:     io.NewIn("size", dsp.Duration(100, c.SampleRate), WithDoc("length of each grain")),
+		density:  io.NewIn("density", dsp.Frequency(10, c.SampleRate), WithDoc("rate at which grains are spawned at random; 0 spawns grains only on trigger")),
+		position: io.NewIn("position", dsp.Float64(0), WithRange(0, 1), WithDoc("where grains start in the buffer")),
+		jitter:   io.NewIn("jitter", dsp.Float64(0), WithRange(0, 1), WithDoc("amount of random variation in the start position")),
+		pitch:    io.NewIn("pitch", dsp.Float64(0), WithMeasure(MeasureSemitones), WithDoc("transposition of grains")),
+		window:   io.NewIn("window", dsp.Float64(grainWindowHann), WithMeasure(MeasureMode), WithRange(0, 3), WithDoc("grain envelope: hann, triangle, tukey or gaussian")),
+		spread:   io.NewIn("spread", dsp.Float64(0), WithRange(0, 1), WithDoc("amount of random stereo panning of grains")),
+		trigger:  io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("spawns a grain")),
+		a:        io.NewOut("a", WithDoc("left channel")),
+		b:        io.NewOut("b", WithDoc("right channel")),
+		grains:   make([]grain, config.Grains),
+		rateMult: 1,
+
+		lastTrigger: -1,
+	}
+
+	if config.File != "" || config.Buffer != "" {
+		buf, err := c.loadBuffer(config.File, config.Buffer)
+		if err != nil {
+			return nil, err
+		}
+		g.buffer = buf.Mono()
+		g.rateMult = sampleRateRatio(buf.SampleRate, c.SampleRate)
+	} else {
+		length := config.Length.Float64()
+		if config.Length.Valuer == nil {
+			length = dsp.Duration(defaultGranularLength, c.SampleRate).Float64()
+		}
+		if length < 1 {
+			return nil, c.errorf("length must be at least one sample long")
+		}
+		g.buffer = make([]float64, int(length))
+		g.live = true
+		g.in = io.NewIn("in", dsp.Float64(0), WithDoc("audio recorded into the buffer"))
+		g.freeze = io.NewIn("freeze", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("stops recording while high"))
+	}
+
+	return NewUnit(io, g), nil
+}
+
+// grain is a single voice of the granular unit.
+type grain struct {
+	active              bool
+	pos, rate           float64
+	age, length         int
+	window              func(float64) float64
+	leftGain, rightGain float64
+}
+
+type granular struct {
+	rand                                                            *rand.Rand
+	in, freeze                                                      *In
+	size, density, position, jitter, pitch, window, spread, trigger *In
+	a, b                                                            *Out
+
+	buffer   []float64
+	live     bool
+	write    int
+	rateMult float64
+	grains   []grain
+
+	lastTrigger float64
+}
+
+func (g *granular) ProcessSample(i int) {
+	if g.live && g.freeze.Read(i) <= 0 {
+		g.buffer[g.write] = g.in.Read(i)
+		g.write = (g.write + 1) % len(g.buffer)
+	}
+
+	trigger := g.trigger.Read(i)
+	if isTrig(g.lastTrigger, trigger) || g.rand.Float64() < g.density.Read(i) {
+		g.spawn(i)
+	}
+	g.lastTrigger = trigger
+
+	var left, right float64
+	for j := range g.grains {
+		gr := &g.grains[j]
+		if !gr.active {
+			continue
+		}
+		v := g.read(gr.pos) * gr.window(float64(gr.age)/float64(gr.length))
+		left += v * gr.leftGain
+		right += v * gr.rightGain
+
+		gr.pos += gr.rate
+		gr.age++
+		if gr.age >= gr.length {
+			gr.active = false
+		}
+	}
+	g.a.Write(i, left)
+	g.b.Write(i, right)
+}
+
+// spawn starts a new grain in the first free voice. If all voices are busy, no grain is started.
+func (g *granular) spawn(i int) {
+	var gr *grain
+	for j := range g.grains {
+		if !g.grains[j].active {
+			gr = &g.grains[j]
+			break
+		}
+	}
+	if gr == nil {
+		return
+	}
+
+	var (
+		length   = int(math.Max(1, g.size.Read(i)))
+		rate     = math.Pow(2, g.pitch.Read(i)/12) * g.rateMult
+		size     = float64(len(g.buffer))
+		jitter   = dsp.Clamp(g.jitter.Read(i), 0, 1)
+		position = g.position.Read(i) + jitter*(g.rand.Float64()*2-1)
+		window   = int(dsp.Clamp(g.window.Read(i), 0, float64(len(grainWindows)-1)))
+		pan      = dsp.Clamp(g.spread.Read(i), 0, 1) * (g.rand.Float64()*2 - 1)
+	)
+
+	start := wrapPhase(position) * size
+	if g.live {
+		// Position is measured backwards from the most recently recorded sample. Grains start far enough back that
+		// they never overtake the recording.
+		back := math.Max(wrapPhase(position)*size, math.Max(rate, 0)*float64(length)+1)
+		start = float64(g.write) - back
+	}
+
+	*gr = grain{
+		active:    true,
+		pos:       start,
+		rate:      rate,
+		length:    length,
+		window:    grainWindows[window],
+		leftGain:  math.Min(1, 1-pan),
+		rightGain: math.Min(1, 1+pan),
+	}
+}
+
+// read returns the buffer's value at a fractional position, wrapping around its ends.
+func (g *granular) read(pos float64) float64 {
+	size := len(g.buffer)
+	pos = math.Mod(pos, float64(size))
+	if pos < 0 {
+		pos += float64(size)
+	}
+	j := int(pos)
+	return dsp.Lerp(g.buffer[j%size], g.buffer[(j+1)%size], pos-float64(j))
+}
@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+func TestGranular_File(t *testing.T) {
+	samples := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = 0.5
+	}
+	u := newTestUnit(t, "granular", map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples)})
+	g := u.SampleProcessor.(*granular)
+	require.False(t, g.live)
+	require.Nil(t, u.In["in"])
+
+	for i := 0; i < frameSize; i++ {
+		u.In["density"].Write(i, 0)
+		u.In["size"].Write(i, 100)
+		u.In["trigger"].Write(i, -1)
+	}
+	u.In["trigger"].Write(1, 1)
+	u.ProcessFrame(frameSize)
+
+	a, b := u.Out["a"].Out(), u.Out["b"].Out()
+	require.Equal(t, 0.0, a.Read(0))
+	require.Equal(t, 0.0, a.Read(1)) // the window starts at zero
+	require.InDelta(t, 0.5*dsp.Hann(0.5), a.Read(51), 1e-3)
+	require.InDelta(t, 0.5*dsp.Hann(0.25), a.Read(26), 1e-3)
+	require.Equal(t, 0.0, a.Read(101))
+	for i := 0; i < frameSize; i++ {
+		require.Equal(t, a.Read(i), b.Read(i))
+	}
+}
+
+func TestGranular_Live(t *testing.T) {
+	u := newTestUnit(t, "granular", map[string]any{"length": dsp.Duration(10, sampleRate)})
+	g := u.SampleProcessor.(*granular)
+	require.True(t, g.live)
+	require.Len(t, g.buffer, 441)
+
+	for i := 0; i < frameSize; i++ {
+		u.In["in"].Write(i, 1)
+		u.In["density"].Write(i, 0)
+		u.In["size"].Write(i, 50)
+		u.In["window"].Write(i, grainWindowTukey)
+		u.In["spread"].Write(i, 1)
+		u.In["trigger"].Write(i, -1)
+	}
+	u.In["trigger"].Write(100, 1)
+	u.ProcessFrame(frameSize)
+
+	a, b := u.Out["a"].Out(), u.Out["b"].Out()
+	require.Equal(t, 0.0, a.Read(99))
+	require.True(t, a.Read(125) > 0 || b.Read(125) > 0)
+	require.NotEqual(t, a.Read(125), b.Read(125))
+
+	u.In["trigger"].Write(100, -1)
+	u.ProcessFrame(frameSize)
+
+	// Frozen buffers keep their contents.
+	for i := 0; i < frameSize; i++ {
+		u.In["in"].Write(i, 0)
+		u.In["freeze"].Write(i, 1)
+	}
+	u.ProcessFrame(frameSize)
+	for _, v := range g.buffer {
+		require.Equal(t, 1.0, v)
+	}
+}
+
+func TestGranular_Voices(t *testing.T) {
+	u := newTestUnit(t, "granular", map[string]any{"grains": 1})
+	g := u.SampleProcessor.(*granular)
+	require.Len(t, g.grains, 1)
+
+	for i := 0; i < 4; i++ {
+		u.In["density"].Write(i, 0)
+		u.In["size"].Write(i, 100)
+		u.In["trigger"].Write(i, float64(i%2)*2-1)
+		u.ProcessSample(i)
+	}
+	require.True(t, g.grains[0].active)
+	require.Equal(t, 3, g.grains[0].age)
+
+	_, err := Builders()["granular"](Config{Values: map[string]any{"grains": -1}, Rand: randtest.Static()})
+	require.EqualError(t, err, "unit/granular: grains must be positive; got -1")
+}
@@ -17,7 +17,8 @@ const (
 	Sample
 )
 
-const controlPeriod = 64
+// DefaultControlPeriod is the default number of samples between reads of ReadSlow and ReadSlowInt.
+const DefaultControlPeriod = 64
 
 // In is a unit input
 type In struct {
@@ -28,18 +29,31 @@ type In struct {
 	unit               *Unit
 	source             *Out
 	node               *graph.Node
+	meta               Meta
 
-	controlLastF float64
-	controlLastI int
+	controlPeriod int
+	controlLastF  float64
+	controlLastI  int
+
+	ramp ramp
+}
+
+// ramp is an in-progress linear transition between two constant values.
+type ramp struct {
+	active   bool
+	from, to float64
+	pos, n   int
 }
 
 // NewIn returns a new input
-func NewIn(name string, v dsp.Valuer, frameSize int) *In {
+func NewIn(name string, v dsp.Valuer, frameSize int, opts ...MetaOption) *In {
 	f := make([]float64, frameSize)
 	in := &In{
-		name:        name,
-		frame:       f,
-		normalFrame: f,
+		name:          name,
+		frame:         f,
+		normalFrame:   f,
+		meta:          newMeta(v, opts),
+		controlPeriod: DefaultControlPeriod,
 	}
 	in.setNormal(v)
 	return in
@@ -47,9 +61,6 @@ func NewIn(name string, v dsp.Valuer, frameSize int) *In {
 
 // Read reads a specific sample from the input frame
 func (in *In) Read(i int) float64 {
-	if isSourceControlRate(in) {
-		return in.frame[0]
-	}
 	if in.mode == Sample {
 		size := len(in.frame)
 		i = (i - 1 + size) % size
@@ -57,30 +68,103 @@ func (in *In) Read(i int) float64 {
 	return in.frame[i]
 }
 
-// ReadSlow reads a specific sample from the input frame at a slow rate
+// ReadSlow reads a specific sample from the input frame at a slow rate; once per control period.
 func (in *In) ReadSlow(i int, f func(float64) float64) float64 {
-	if i%controlPeriod == 0 {
+	if i%in.controlPeriod == 0 {
 		in.controlLastF = f(in.Read(i))
 	}
 	return in.controlLastF
 }
 
-// ReadSlowInt reads a specific sample from the input frame at a slow rate
+// ReadSlowInt reads a specific sample from the input frame at a slow rate; once per control period.
 func (in *In) ReadSlowInt(i int, f func(float64) int) int {
-	if i%controlPeriod == 0 {
+	if i%in.controlPeriod == 0 {
 		in.controlLastI = f(in.Read(i))
 	}
 	return in.controlLastI
 }
 
+// readPeak returns the largest of the first n samples of the frame. Units processed at control rate use it to avoid
+// missing triggers and gates that occur after the first sample of a frame.
+func (in *In) readPeak(n int) float64 {
+	if n > len(in.frame) {
+		n = len(in.frame)
+	}
+	peak := in.frame[0]
+	for _, v := range in.frame[1:n] {
+		if v > peak {
+			peak = v
+		}
+	}
+	return peak
+}
+
 // Fill fills the internal frame with a specific constant value
 func (in *In) Fill(v dsp.Valuer) {
+	in.ramp.active = false
 	for i := range in.frame {
 		in.frame[i] = v.Float64()
 	}
 	in.constant = v
 }
 
+// Ramp transitions the input from one value to a new constant value over a number of samples, rather than jumping to
+// it immediately. The transition is advanced by the owning Unit as it processes frames. Inputs that aren't attached to
+// a Unit, ramps of zero samples and inputs that take discrete values (gates, triggers, modes, integers and unsmoothed
+// inputs) are filled immediately.
+func (in *In) Ramp(from float64, to dsp.Valuer, samples int) {
+	if samples <= 0 || in.unit == nil || in.HasSource() || in.discrete() {
+		in.Fill(to)
+		return
+	}
+	in.constant = to
+	in.ramp = ramp{
+		active: true,
+		from:   from,
+		to:     to.Float64(),
+		n:      samples,
+	}
+	for i := range in.frame {
+		in.frame[i] = from
+	}
+	in.unit.addRamp(in)
+}
+
+// discrete returns whether the input takes discrete values, whose edges and steps would be blurred by a Ramp.
+func (in *In) discrete() bool {
+	switch in.meta.Measure {
+	case MeasureGate, MeasureTrigger, MeasureMode, MeasureInteger:
+		return true
+	}
+	return in.meta.Unsmoothed
+}
+
+// Current returns the most recent value the input has been holding. It's used as the starting point of a Ramp.
+func (in *In) Current() float64 {
+	return in.frame[len(in.frame)-1]
+}
+
+// advanceRamp writes the next n samples of an active ramp into the frame. It returns false once the ramp has completed
+// or been interrupted.
+func (in *In) advanceRamp(n int) bool {
+	r := &in.ramp
+	if !r.active || in.HasSource() {
+		r.active = false
+		return false
+	}
+	if r.pos >= r.n {
+		in.Fill(in.constant)
+		return false
+	}
+	for i := 0; i < n && i < len(in.frame); i++ {
+		if r.pos < r.n {
+			r.pos++
+		}
+		in.frame[i] = r.from + (r.to-r.from)*float64(r.pos)/float64(r.n)
+	}
+	return true
+}
+
 // Write writes a sample to the internal buffer
 func (in *In) Write(i int, v float64) {
 	in.frame[i] = v
@@ -92,6 +176,7 @@ func (in *In) Couple(out Output) {
 	o := out.Out()
 	in.source = o
 	in.frame = o.frame
+	in.ramp.active = false
 }
 
 // HasSource returns whether or not we have an inbound connection
@@ -118,6 +203,11 @@ func (in *In) Reset() {
 	in.Fill(in.normal)
 }
 
+// Meta returns the descriptive information for this input.
+func (in *In) Meta() Meta {
+	return in.meta
+}
+
 // SetMode sets the processing mode for this input.
 func (in *In) SetMode(mode InMode) {
 	in.mode = mode
@@ -137,10 +227,6 @@ func (in *In) String() string {
 	return fmt.Sprintf("%s/%s", in.unit.ID, in.name)
 }
 
-func isSourceControlRate(in *In) bool {
-	return in.HasSource() && in.source.Rate() == RateControl
-}
-
 func ident(v float64) float64 { return v }
 func clamp(min, max float64) func(float64) float64 {
 	return func(v float64) float64 {
@@ -75,13 +75,72 @@ func TestIn_CoupleOutput(t *testing.T) {
 
 func TestIn_ReadControlRate(t *testing.T) {
 	in := NewIn("in", dsp.Float64(0), frameSize)
-	in.mode = Sample
-	in.Couple(&Out{
+	out := &Out{
 		unit:  &Unit{rate: RateControl},
 		frame: make([]float64, frameSize),
-	})
-	in.frame[0] = 10
-	in.frame[5] = 20
-	require.Equal(t, 10.0, in.Read(0))
-	require.Equal(t, 10.0, in.Read(5))
+	}
+	in.Couple(out)
+
+	// Control rate values are spread across the frame for audio rate consumers
+	out.Write(0, 8)
+	out.expand(4)
+	require.Equal(t, 2.0, in.Read(0))
+	require.Equal(t, 6.0, in.Read(2))
+	require.Equal(t, 8.0, in.Read(3))
+
+	out.Write(0, 0)
+	out.expand(4)
+	require.Equal(t, 6.0, in.Read(0))
+	require.Equal(t, 0.0, in.Read(3))
+
+	// Gates are held rather than interpolated
+	out.meta.Measure = MeasureGate
+	out.Write(0, 1)
+	out.expand(4)
+	require.Equal(t, 1.0, in.Read(0))
+	require.Equal(t, 1.0, in.Read(3))
+}
+
+func TestIn_Ramp(t *testing.T) {
+	io := NewIO("example", frameSize)
+	in := io.NewIn("in", dsp.Float64(0))
+	u := NewUnit(io, nil)
+	require.NoError(t, u.Attach(graph.New()))
+
+	in.Ramp(in.Current(), dsp.Float64(4), 4)
+	require.Equal(t, 0.0, in.Read(0))
+	require.Len(t, u.ramps, 1)
+
+	u.AdvanceRamps(frameSize)
+	require.Equal(t, []float64{1, 2, 3, 4, 4}, in.frame[:5])
+	require.Equal(t, 4.0, in.Read(frameSize-1))
+
+	u.AdvanceRamps(frameSize)
+	require.Empty(t, u.ramps)
+
+	in.Ramp(in.Current(), dsp.Float64(0), 8)
+	in.Fill(dsp.Float64(2))
+	u.AdvanceRamps(frameSize)
+	require.Empty(t, u.ramps)
+	require.Equal(t, 2.0, in.Read(0))
+}
+
+func TestIn_RampDiscrete(t *testing.T) {
+	io := NewIO("example", frameSize)
+	gate := io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate))
+	mode := io.NewIn("mode", dsp.Float64(0), WithMeasure(MeasureMode))
+	index := io.NewIn("index", dsp.Float64(0), WithMeasure(MeasureInteger))
+	step := io.NewIn("step", dsp.Float64(0), WithoutSmoothing())
+	u := NewUnit(io, nil)
+	require.NoError(t, u.Attach(graph.New()))
+
+	gate.Ramp(gate.Current(), dsp.Float64(1), 64)
+	mode.Ramp(mode.Current(), dsp.Float64(2), 64)
+	index.Ramp(index.Current(), dsp.Float64(3), 64)
+	step.Ramp(step.Current(), dsp.Float64(0.5), 64)
+	require.Empty(t, u.ramps)
+	require.Equal(t, 1.0, gate.Read(0))
+	require.Equal(t, 2.0, mode.Read(0))
+	require.Equal(t, 3.0, index.Read(0))
+	require.Equal(t, 0.5, step.Read(0))
 }
@@ -13,11 +13,11 @@ func newInterpolate(io *IO, c Config) (*Unit, error) {
 	}
 
 	return NewUnit(io, &interpolate{
-		in:      io.NewIn("in", dsp.Float64(0)),
-		min:     io.NewIn("min", dsp.Float64(0)),
-		max:     io.NewIn("max", dsp.Float64(1)),
-		scale:   io.NewIn("scale", dsp.Float64(1)),
-		out:     io.NewOut("out"),
+		in:      io.NewIn("in", dsp.Float64(0), WithRange(0, 1), WithDoc("position between min and max")),
+		min:     io.NewIn("min", dsp.Float64(0), WithDoc("output when in is 0")),
+		max:     io.NewIn("max", dsp.Float64(1), WithDoc("output when in is 1")),
+		scale:   io.NewIn("scale", dsp.Float64(1), WithDoc("multiplier applied to the output")),
+		out:     io.NewOut("out", WithDoc("interpolated value")),
 		average: dsp.RollingAverage{Window: int(config.SmoothTime.Float64())},
 	}), nil
 }
@@ -16,6 +16,8 @@ type IO struct {
 	In        map[string]*In
 	Out       map[string]Output
 	frameSize int
+
+	controlPeriod int
 }
 
 // NewIO returns a new IO
@@ -27,13 +29,23 @@ func NewIO(typ string, frameSize int) *IO {
 		In:        map[string]*In{},
 		Out:       map[string]Output{},
 		frameSize: frameSize,
+
+		controlPeriod: DefaultControlPeriod,
 	}
 	atomic.AddUint32(&idCount, 1)
 	return io
 }
 
+// SetControlPeriod sets the number of samples between reads of ReadSlow and ReadSlowInt for inputs registered from
+// this point forward.
+func (io *IO) SetControlPeriod(n int) {
+	if n > 0 {
+		io.controlPeriod = n
+	}
+}
+
 // NewProp registers a new property
-func (io *IO) NewProp(name string, v any, setter func(*Prop, any) error) *Prop {
+func (io *IO) NewProp(name string, v any, setter func(*Prop, any) error, opts ...MetaOption) *Prop {
 	switch assert := v.(type) {
 	case int:
 		v = float64(assert)
@@ -42,26 +54,28 @@ func (io *IO) NewProp(name string, v any, setter func(*Prop, any) error) *Prop {
 		name:   name,
 		value:  v,
 		setter: setter,
+		meta:   newMeta(v, opts),
 	}
 	io.Prop[p.name] = p
 	return p
 }
 
 // NewIn registers a new input
-func (io *IO) NewIn(name string, v dsp.Valuer) *In {
-	in := NewIn(name, v, io.frameSize)
+func (io *IO) NewIn(name string, v dsp.Valuer, opts ...MetaOption) *In {
+	in := NewIn(name, v, io.frameSize, opts...)
+	in.controlPeriod = io.controlPeriod
 	io.In[in.name] = in
 	return in
 }
 
 // NewOut registers a new output
-func (io *IO) NewOut(name string) *Out {
-	return io.newOut(name, make([]float64, io.frameSize))
+func (io *IO) NewOut(name string, opts ...MetaOption) *Out {
+	return io.newOut(name, make([]float64, io.frameSize), opts)
 }
 
 // NewOutWithFrame registers a new output that has a specific frame
-func (io *IO) NewOutWithFrame(name string, f []float64) *Out {
-	return io.newOut(name, f)
+func (io *IO) NewOutWithFrame(name string, f []float64, opts ...MetaOption) *Out {
+	return io.newOut(name, f, opts)
 }
 
 // ExposeOutputProcessor registers a new output that is also a Processor
@@ -69,11 +83,8 @@ func (io *IO) ExposeOutputProcessor(o OutputProcessor) {
 	io.Out[o.Out().name] = o
 }
 
-func (io *IO) newOut(name string, f []float64) *Out {
-	o := &Out{
-		name:  name,
-		frame: f,
-	}
+func (io *IO) newOut(name string, f []float64, opts []MetaOption) *Out {
+	o := NewOut(name, f, opts...)
 	io.Out[name] = o
 	return o
 }
@@ -60,3 +60,25 @@ func TestExposeProp(t *testing.T) {
 	p := io.NewProp("x", 1, nil)
 	require.Equal(t, p, io.Prop["x"])
 }
+
+func TestDescribe(t *testing.T) {
+	io := NewIO("example", frameSize)
+	io.NewIn("freq", dsp.Frequency(440, sampleRate), WithRange(0, 22050), WithDoc("frequency"))
+	io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate))
+	io.NewOut("out", WithDoc("output"))
+	io.NewProp("mode", 0, nil, WithMeasure(MeasureMode))
+
+	require.Equal(t, Description{
+		Type: "example",
+		Inputs: []PortDescription{
+			{Name: "freq", Default: "440.00Hz", Measure: MeasureHz, Range: []float64{0, 22050}, Doc: "frequency"},
+			{Name: "gate", Default: "-1.00", Measure: MeasureGate},
+		},
+		Outputs: []PortDescription{
+			{Name: "out", Doc: "output"},
+		},
+		Props: []PortDescription{
+			{Name: "mode", Default: "0", Measure: MeasureMode},
+		},
+	}, io.Describe())
+}
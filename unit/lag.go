@@ -8,10 +8,10 @@ import (
 
 func newLag(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &lag{
-		in:   io.NewIn("in", dsp.Float64(0)),
-		rise: io.NewIn("rise", dsp.Duration(5, c.SampleRate)),
-		fall: io.NewIn("fall", dsp.Duration(5, c.SampleRate)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithDoc("signal to slew")),
+		rise: io.NewIn("rise", dsp.Duration(5, c.SampleRate), WithDoc("time taken to rise to a new value")),
+		fall: io.NewIn("fall", dsp.Duration(5, c.SampleRate), WithDoc("time taken to fall to a new value")),
+		out:  io.NewOut("out", WithDoc("slewed signal")),
 		slew: newSlew(),
 	}), nil
 }
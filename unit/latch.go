@@ -7,9 +7,9 @@ import (
 func newLatch(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &latch{
 		lastTrigger: -1,
-		in:          io.NewIn("in", dsp.Float64(0)),
-		trigger:     io.NewIn("trigger", dsp.Float64(0)),
-		out:         io.NewOut("out"),
+		in:          io.NewIn("in", dsp.Float64(0), WithDoc("signal to sample")),
+		trigger:     io.NewIn("trigger", dsp.Float64(0), WithMeasure(MeasureTrigger), WithDoc("samples the input")),
+		out:         io.NewOut("out", WithDoc("last sampled value")),
 		initial:     false,
 	}), nil
 }
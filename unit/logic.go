@@ -15,10 +15,10 @@ type logicMode int
 
 func newLogic(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &logic{
-		x:    io.NewIn("x", dsp.Float64(0)),
-		y:    io.NewIn("y", dsp.Float64(0)),
-		mode: io.NewIn("mode", dsp.Float64(logicOR)),
-		out:  io.NewOut("out"),
+		x:    io.NewIn("x", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("first operand")),
+		y:    io.NewIn("y", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("second operand")),
+		mode: io.NewIn("mode", dsp.Float64(logicOR), WithMeasure(MeasureMode), WithRange(0, 5), WithDoc("or, and, xor, nor, nand or xnor")),
+		out:  io.NewOut("out", WithMeasure(MeasureGate), WithDoc("result of the operation")),
 	}), nil
 }
 
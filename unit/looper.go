@@ -0,0 +1,193 @@
+package unit
+
+import (
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const defaultLooperLength = 30000 // ms
+
+func newLooper(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Length dsp.MS
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	length := config.Length.Float64()
+	if config.Length.Valuer == nil {
+		length = dsp.Duration(defaultLooperLength, c.SampleRate).Float64()
+	}
+	if length < 1 {
+		return nil, c.errorf("length must be at least one sample long")
+	}
+
+	return NewUnit(io, &looper{
+		in:         io.NewIn("in", dsp.Float64(0), WithDoc("audio to record")),
+		record:     io.NewIn("record", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("records the first take, then overdubs, while high")),
+		play:       io.NewIn("play", dsp.Float64(1), WithMeasure(MeasureGate), WithDoc("plays the loop while high; restarts it when going high")),
+		reverse:    io.NewIn("reverse", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("plays the loop backwards while high")),
+		rate:       io.NewIn("rate", dsp.Float64(1), WithDoc("playback speed where 1 is the recorded speed")),
+		feedback:   io.NewIn("feedback", dsp.Float64(1), WithRange(0, 1), WithDoc("amount of the loop kept when overdubbing")),
+		clock:      io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("when patched, the first take starts and ends on clock ticks")),
+		clear:      io.NewIn("clear", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("erases the loop")),
+		out:        io.NewOut("out", WithDoc("loop playback")),
+		buffer:     make([]float64, int(length)+1),
+		frame:      make([]float64, 1),
+		sampleRate: c.SampleRate,
+
+		lastRecord: -1,
+		lastPlay:   1,
+		lastClock:  -1,
+		lastClear:  -1,
+	}), nil
+}
+
+type looperState int
+
+const (
+	looperEmpty     looperState = iota
+	looperArmed                 // waiting for a clock tick to start the first take
+	looperRecording             // recording the first take
+	looperClosing               // waiting for a clock tick to end the first take
+	looperLooping               // playing back and overdubbing
+)
+
+// looper records a first take of its input to set the length of a loop, then plays that loop back while overdubbing
+// further takes onto it. The buffer holds one sample beyond the end of the loop that mirrors its first sample, so
+// playback interpolates cleanly across the seam.
+type looper struct {
+	in, record, play, reverse, rate, feedback, clock, clear *In
+	out                                                     *Out
+
+	buffer     []float64
+	frame      []float64
+	length     int
+	state      looperState
+	playhead   playhead
+	sampleRate int
+
+	lastRecord, lastPlay, lastClock, lastClear float64
+}
+
+func (l *looper) ProcessSample(i int) {
+	var (
+		in     = l.in.Read(i)
+		record = l.record.Read(i)
+		play   = l.play.Read(i)
+		clock  = l.clock.Read(i)
+		clear  = l.clear.Read(i)
+		ticked = isTrig(l.lastClock, clock)
+		snap   = l.clock.HasSource()
+	)
+
+	if isTrig(l.lastClear, clear) {
+		l.erase()
+	}
+
+	switch l.state {
+	case looperEmpty:
+		if isTrig(l.lastRecord, record) {
+			l.state = looperRecording
+			if snap && !ticked {
+				l.state = looperArmed
+			}
+		}
+	case looperArmed:
+		if record <= 0 {
+			l.state = looperEmpty
+		} else if ticked {
+			l.state = looperRecording
+		}
+	case looperRecording:
+		if record <= 0 {
+			l.state = looperClosing
+		}
+	}
+	if l.state == looperClosing && (!snap || ticked) {
+		l.close()
+	}
+
+	switch l.state {
+	case looperRecording, looperClosing:
+		l.buffer[l.length] = in
+		l.length++
+		if l.length == len(l.buffer)-1 {
+			l.close()
+		}
+		l.out.Write(i, 0)
+	case looperLooping:
+		if isTrig(l.lastPlay, play) {
+			l.playhead.start(0, float64(l.length), l.speed(i))
+		}
+		if play <= 0 {
+			l.out.Write(i, 0)
+			break
+		}
+		pos := l.playhead.pos
+		l.playhead.tick(l.frame, 0, float64(l.length), l.speed(i), 0, true)
+		l.out.Write(i, l.frame[0])
+		if record > 0 {
+			l.overdub(pos, in, l.feedback.Read(i))
+		}
+	default:
+		l.out.Write(i, 0)
+	}
+
+	l.lastRecord = record
+	l.lastPlay = play
+	l.lastClock = clock
+	l.lastClear = clear
+}
+
+func (l *looper) speed(i int) float64 {
+	speed := l.rate.Read(i)
+	if l.reverse.Read(i) > 0 {
+		return -speed
+	}
+	return speed
+}
+
+// close ends the first take and starts looping it. A take too short to loop is discarded.
+func (l *looper) close() {
+	if l.length < 2 {
+		l.erase()
+		return
+	}
+	l.buffer[l.length] = l.buffer[0]
+	l.playhead = playhead{data: l.buffer[:l.length+1], channels: 1}
+	l.playhead.start(0, float64(l.length), 1)
+	l.state = looperLooping
+}
+
+// overdub mixes a sample of input into the loop at a position.
+func (l *looper) overdub(pos, in, feedback float64) {
+	j := int(pos)
+	if j >= l.length {
+		j = 0
+	}
+	l.buffer[j] = l.buffer[j]*feedback + in
+	if j == 0 {
+		l.buffer[l.length] = l.buffer[0]
+	}
+}
+
+func (l *looper) erase() {
+	for j := range l.buffer[:l.length+1] {
+		l.buffer[j] = 0
+	}
+	l.length = 0
+	l.state = looperEmpty
+	l.playhead = playhead{}
+}
+
+// Buffer returns a copy of the loop. While the first take is being recorded, it contains the take so far.
+func (l *looper) Buffer() *Buffer {
+	data := make([]float64, l.length)
+	copy(data, l.buffer)
+	return &Buffer{
+		Data:       data,
+		Channels:   1,
+		SampleRate: l.sampleRate,
+	}
+}
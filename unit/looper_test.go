@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// stepLooper writes a single sample to each named input and processes it.
+func stepLooper(u *Unit, i int, inputs map[string]float64) float64 {
+	for name, v := range inputs {
+		u.In[name].Write(i, v)
+	}
+	u.ProcessSample(i)
+	return u.Out["out"].Out().Read(i)
+}
+
+func TestLooper(t *testing.T) {
+	u := newTestUnit(t, "looper", map[string]any{"length": dsp.Duration(10, sampleRate)})
+	l := u.SampleProcessor.(*looper)
+	require.Len(t, l.buffer, 442)
+
+	// Record a first take of 10 samples.
+	for i := 0; i < 10; i++ {
+		require.Equal(t, 0.0, stepLooper(u, i, map[string]float64{"record": 1, "in": float64(i + 1)}))
+	}
+	require.Equal(t, looperRecording, l.state)
+
+	// Releasing the gate starts the loop.
+	var out []float64
+	for i := 0; i < 20; i++ {
+		out = append(out, stepLooper(u, i, map[string]float64{"record": -1, "in": 100}))
+	}
+	require.Equal(t, looperLooping, l.state)
+	require.Equal(t, 10, l.length)
+	require.Equal(t, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, out)
+
+	// Overdub a take at half volume of the existing loop.
+	for i := 0; i < 10; i++ {
+		stepLooper(u, i, map[string]float64{"record": 1, "in": 1, "feedback": 0.5})
+	}
+	out = nil
+	for i := 0; i < 10; i++ {
+		out = append(out, stepLooper(u, i, map[string]float64{"record": -1}))
+	}
+	require.Equal(t, []float64{1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5, 5.5, 6}, out)
+
+	buf := l.Buffer()
+	require.Equal(t, []float64{1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5, 5.5, 6}, buf.Data)
+	require.Equal(t, 1, buf.Channels)
+	require.Equal(t, int(sampleRate), buf.SampleRate)
+
+	// Reverse.
+	out = nil
+	for i := 0; i < 4; i++ {
+		out = append(out, stepLooper(u, i, map[string]float64{"reverse": 1}))
+	}
+	require.Equal(t, []float64{1.5, 6, 5.5, 5}, out)
+
+	// Clear.
+	stepLooper(u, 0, map[string]float64{"clear": 1})
+	require.Equal(t, looperEmpty, l.state)
+	require.Equal(t, 0, l.length)
+	require.Equal(t, 0.0, stepLooper(u, 1, map[string]float64{"clear": -1}))
+}
+
+func TestLooper_ClockSnap(t *testing.T) {
+	u := newTestUnit(t, "looper", map[string]any{"length": dsp.Duration(10, sampleRate)})
+	l := u.SampleProcessor.(*looper)
+	clock := NewOut("out", make([]float64, frameSize))
+	u.In["clock"].Couple(clock)
+
+	tick := func(i int, v float64) {
+		clock.Write(i, v)
+	}
+
+	// The gate goes high between ticks; recording waits for the next one.
+	tick(0, -1)
+	stepLooper(u, 0, map[string]float64{"record": 1, "in": 1})
+	require.Equal(t, looperArmed, l.state)
+	tick(1, 1)
+	stepLooper(u, 1, map[string]float64{"record": 1, "in": 2})
+	require.Equal(t, looperRecording, l.state)
+
+	// The gate falls early; recording continues until the next tick.
+	for i := 2; i < 8; i++ {
+		tick(i, -1)
+		stepLooper(u, i, map[string]float64{"record": -1, "in": float64(i + 1)})
+	}
+	require.Equal(t, looperClosing, l.state)
+	tick(8, 1)
+	require.Equal(t, 2.0, stepLooper(u, 8, map[string]float64{"record": -1, "in": 9}))
+	require.Equal(t, looperLooping, l.state)
+	require.Equal(t, 7, l.length)
+	require.Equal(t, []float64{2, 3, 4, 5, 6, 7, 8}, l.buffer[:7])
+}
@@ -10,11 +10,11 @@ import (
 func newLowGen(io *IO, c Config) (*Unit, error) {
 	g := &lowGen{
 		rand:      c.Rand,
-		freq:      io.NewIn("freq", dsp.Frequency(1, c.SampleRate)),
-		amp:       io.NewIn("amp", dsp.Float64(1)),
-		pw:        io.NewIn("pulse-width", dsp.Float64(1)),
-		offset:    io.NewIn("offset", dsp.Float64(0)),
-		sync:      io.NewIn("sync", dsp.Float64(-1)),
+		freq:      io.NewIn("freq", dsp.Frequency(1, c.SampleRate), WithDoc("frequency of the oscillator")),
+		amp:       io.NewIn("amp", dsp.Float64(1), WithDoc("amplitude")),
+		pw:        io.NewIn("pulse-width", dsp.Float64(1), WithRange(0, 2), WithDoc("width of the pulse where 1 is square")),
+		offset:    io.NewIn("offset", dsp.Float64(0), WithDoc("amount added to the output")),
+		sync:      io.NewIn("sync", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("resets the phase")),
 		frameSize: c.FrameSize,
 	}
 
@@ -42,7 +42,7 @@ func (g *lowGen) newSine() *lowGenSine {
 	return &lowGenSine{
 		lowGen: g,
 		phase:  g.rand.Float64() * twoPi,
-		out:    NewOut("sine", g.newFrame()),
+		out:    NewOut("sine", g.newFrame(), WithDoc("sine wave")),
 	}
 }
 
@@ -50,7 +50,7 @@ func (g *lowGen) newSaw() *lowGenSaw {
 	return &lowGenSaw{
 		lowGen: g,
 		phase:  g.rand.Float64() * twoPi,
-		out:    NewOut("saw", g.newFrame()),
+		out:    NewOut("saw", g.newFrame(), WithDoc("sawtooth wave")),
 	}
 }
 
@@ -58,7 +58,7 @@ func (g *lowGen) newPulse() *lowGenPulse {
 	return &lowGenPulse{
 		lowGen: g,
 		phase:  g.rand.Float64() * twoPi,
-		out:    NewOut("pulse", g.newFrame()),
+		out:    NewOut("pulse", g.newFrame(), WithMeasure(MeasureGate), WithDoc("pulse wave; held rather than interpolated between control rate frames")),
 	}
 }
 
@@ -66,7 +66,7 @@ func (g *lowGen) newTriangle() *lowGenTriangle {
 	return &lowGenTriangle{
 		lowGen: g,
 		phase:  g.rand.Float64() * twoPi,
-		out:    NewOut("triangle", g.newFrame()),
+		out:    NewOut("triangle", g.newFrame(), WithDoc("triangle wave")),
 	}
 }
 
@@ -76,22 +76,24 @@ type lowGenSine struct {
 	out             *Out
 }
 
-func (o *lowGenSine) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
-func (o *lowGenSine) Out() *Out           { return o.out }
-func (o *lowGenSine) ProcessFrame(int)    { o.ProcessSample(0) }
+func (o *lowGenSine) IsProcessable() bool  { return o.out.ExternalNeighborCount() > 0 }
+func (o *lowGenSine) Out() *Out            { return o.out }
+func (o *lowGenSine) ProcessFrame(n int)   { ProcessOutputFrame(o, n) }
+func (o *lowGenSine) ProcessControl(n int) { o.step(0, n, o.sync.readPeak(n)) }
+func (o *lowGenSine) ProcessSample(i int)  { o.step(i, 1, o.sync.Read(i)) }
 
-func (o *lowGenSine) ProcessSample(i int) {
+func (o *lowGenSine) step(i, samples int, sync float64) {
 	var (
 		freq   = o.freq.Read(i)
 		amp    = o.amp.Read(i)
 		offset = o.offset.Read(i)
-		sync   = o.sync.Read(i)
 	)
 	if o.lastSync < 0 && sync > 0 {
 		o.phase = 0
 	}
 	next := dsp.Sin(o.phase)
-	o.phase = stepPhase(freq, 0, o.phase, o.frameSize, 1)
+	o.phase = stepPhase(freq, 0, o.phase, samples, 1)
+	o.lastSync = sync
 	o.out.Write(i, (amp*next)+offset)
 }
 
@@ -101,22 +103,24 @@ type lowGenSaw struct {
 	out             *Out
 }
 
-func (o *lowGenSaw) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
-func (o *lowGenSaw) Out() *Out           { return o.out }
-func (o *lowGenSaw) ProcessFrame(int)    { o.ProcessSample(0) }
+func (o *lowGenSaw) IsProcessable() bool  { return o.out.ExternalNeighborCount() > 0 }
+func (o *lowGenSaw) Out() *Out            { return o.out }
+func (o *lowGenSaw) ProcessFrame(n int)   { ProcessOutputFrame(o, n) }
+func (o *lowGenSaw) ProcessControl(n int) { o.step(0, n, o.sync.readPeak(n)) }
+func (o *lowGenSaw) ProcessSample(i int)  { o.step(i, 1, o.sync.Read(i)) }
 
-func (o *lowGenSaw) ProcessSample(i int) {
+func (o *lowGenSaw) step(i, samples int, sync float64) {
 	var (
 		freq   = o.freq.Read(i)
 		amp    = o.amp.Read(i)
 		offset = o.offset.Read(i)
-		sync   = o.sync.Read(i)
 	)
 	if o.lastSync < 0 && sync > 0 {
 		o.phase = 0
 	}
 	next := (2.0*(o.phase)/twoPi - 1.0)
-	o.phase = stepPhase(freq, 0, o.phase, o.frameSize, 1)
+	o.phase = stepPhase(freq, 0, o.phase, samples, 1)
+	o.lastSync = sync
 	o.out.Write(i, (amp*next)+offset)
 }
 
@@ -126,17 +130,18 @@ type lowGenPulse struct {
 	out             *Out
 }
 
-func (o *lowGenPulse) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
-func (o *lowGenPulse) Out() *Out           { return o.out }
-func (o *lowGenPulse) ProcessFrame(int)    { o.ProcessSample(0) }
+func (o *lowGenPulse) IsProcessable() bool  { return o.out.ExternalNeighborCount() > 0 }
+func (o *lowGenPulse) Out() *Out            { return o.out }
+func (o *lowGenPulse) ProcessFrame(n int)   { ProcessOutputFrame(o, n) }
+func (o *lowGenPulse) ProcessControl(n int) { o.step(0, n, o.sync.readPeak(n)) }
+func (o *lowGenPulse) ProcessSample(i int)  { o.step(i, 1, o.sync.Read(i)) }
 
-func (o *lowGenPulse) ProcessSample(i int) {
+func (o *lowGenPulse) step(i, samples int, sync float64) {
 	var (
 		freq   = o.freq.Read(i)
 		amp    = o.amp.Read(i)
 		pw     = math.Abs(o.pw.Read(i))
 		offset = o.offset.Read(i)
-		sync   = o.sync.Read(i)
 		next   float64
 	)
 
@@ -149,7 +154,8 @@ func (o *lowGenPulse) ProcessSample(i int) {
 		next = -1
 	}
 
-	o.phase = stepPhase(freq, 0, o.phase, o.frameSize, 1)
+	o.phase = stepPhase(freq, 0, o.phase, samples, 1)
+	o.lastSync = sync
 	o.out.Write(i, (amp*next)+offset)
 }
 
@@ -159,17 +165,18 @@ type lowGenTriangle struct {
 	out             *Out
 }
 
-func (o *lowGenTriangle) IsProcessable() bool { return o.out.ExternalNeighborCount() > 0 }
-func (o *lowGenTriangle) Out() *Out           { return o.out }
-func (o *lowGenTriangle) ProcessFrame(int)    { o.ProcessSample(0) }
+func (o *lowGenTriangle) IsProcessable() bool  { return o.out.ExternalNeighborCount() > 0 }
+func (o *lowGenTriangle) Out() *Out            { return o.out }
+func (o *lowGenTriangle) ProcessFrame(n int)   { ProcessOutputFrame(o, n) }
+func (o *lowGenTriangle) ProcessControl(n int) { o.step(0, n, o.sync.readPeak(n)) }
+func (o *lowGenTriangle) ProcessSample(i int)  { o.step(i, 1, o.sync.Read(i)) }
 
-func (o *lowGenTriangle) ProcessSample(i int) {
+func (o *lowGenTriangle) step(i, samples int, sync float64) {
 	var (
 		freq   = o.freq.Read(i)
 		amp    = o.amp.Read(i)
 		offset = o.offset.Read(i)
 		p      = o.phase
-		sync   = o.sync.Read(i)
 		next   float64
 	)
 	if o.lastSync < 0 && sync > 0 {
@@ -180,6 +187,7 @@ func (o *lowGenTriangle) ProcessSample(i int) {
 	} else {
 		next = (3 - twoDivPi*p)
 	}
-	o.phase = stepPhase(freq, 0, o.phase, o.frameSize, 1)
+	o.phase = stepPhase(freq, 0, o.phase, samples, 1)
+	o.lastSync = sync
 	o.out.Write(i, (amp*next)+offset)
 }
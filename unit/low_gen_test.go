@@ -28,13 +28,14 @@ func TestLowGen_Sine(t *testing.T) {
 	freq := u.In["freq"]
 	out := u.Out["sine"].(*lowGenSine)
 
-	// Only processes the first sample in frame
+	// Processes once per frame and interpolates from the previous value across the frame
 	freqv := dsp.Frequency(100, sampleRate).Float64()
 	freq.Write(0, freqv)
 	freq.Write(1, freqv)
 	out.ProcessFrame(frameSize)
-	require.NotEqual(t, 0.0, out.Out().Read(0))
-	require.Equal(t, 0.0, out.Out().Read(1))
+	last := out.Out().Read(frameSize - 1)
+	require.NotEqual(t, 0.0, last)
+	require.InDelta(t, last/frameSize, out.Out().Read(0), 1e-12)
 }
 
 func TestLowGen_Saw(t *testing.T) {
@@ -49,13 +50,14 @@ func TestLowGen_Saw(t *testing.T) {
 	freq := u.In["freq"]
 	out := u.Out["saw"].(*lowGenSaw)
 
-	// Only processes the first sample in frame
+	// Processes once per frame and interpolates from the previous value across the frame
 	freqv := dsp.Frequency(100, sampleRate).Float64()
 	freq.Write(0, freqv)
 	freq.Write(1, freqv)
 	out.ProcessFrame(frameSize)
-	require.NotEqual(t, 0.0, out.Out().Read(0))
-	require.Equal(t, 0.0, out.Out().Read(1))
+	last := out.Out().Read(frameSize - 1)
+	require.NotEqual(t, 0.0, last)
+	require.InDelta(t, last/frameSize, out.Out().Read(0), 1e-12)
 }
 
 func TestLowGen_Pulse(t *testing.T) {
@@ -70,13 +72,16 @@ func TestLowGen_Pulse(t *testing.T) {
 	freq := u.In["freq"]
 	out := u.Out["pulse"].(*lowGenPulse)
 
-	// Only processes the first sample in frame
+	// Processes once per frame and holds the value across the frame, so its edges stay square
 	freqv := dsp.Frequency(100, sampleRate).Float64()
 	freq.Write(0, freqv)
 	freq.Write(1, freqv)
 	out.ProcessFrame(frameSize)
-	require.NotEqual(t, 0.0, out.Out().Read(0))
-	require.Equal(t, 0.0, out.Out().Read(1))
+	last := out.Out().Read(frameSize - 1)
+	require.NotEqual(t, 0.0, last)
+	for i := 0; i < frameSize; i++ {
+		require.Equal(t, last, out.Out().Read(i))
+	}
 }
 
 func TestLowGen_Triangle(t *testing.T) {
@@ -91,11 +96,27 @@ func TestLowGen_Triangle(t *testing.T) {
 	freq := u.In["freq"]
 	out := u.Out["triangle"].(*lowGenTriangle)
 
-	// Only processes the first sample in frame
+	// Processes once per frame and interpolates from the previous value across the frame
 	freqv := dsp.Frequency(100, sampleRate).Float64()
 	freq.Write(0, freqv)
 	freq.Write(1, freqv)
 	out.ProcessFrame(frameSize)
-	require.NotEqual(t, 0.0, out.Out().Read(0))
-	require.Equal(t, 0.0, out.Out().Read(1))
+	last := out.Out().Read(frameSize - 1)
+	require.NotEqual(t, 0.0, last)
+	require.InDelta(t, last/frameSize, out.Out().Read(0), 1e-12)
+}
+
+func TestLowGen_SyncWithinFrame(t *testing.T) {
+	u := newTestUnit(t, "low-gen", nil)
+	sync := u.In["sync"]
+	out := u.Out["saw"].(*lowGenSaw)
+
+	// A sync trigger after the first sample of the frame still resets the phase.
+	for i := 0; i < frameSize; i++ {
+		sync.Write(i, -1)
+	}
+	out.ProcessFrame(frameSize)
+	sync.Write(100, 1)
+	out.ProcessFrame(frameSize)
+	require.Equal(t, -1.0, out.Out().Read(frameSize-1))
 }
@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// Measure is the unit of measure of the values accepted by an input or property, or produced by an output.
+type Measure string
+
+// Measures
+const (
+	MeasureNone      Measure = ""
+	MeasureHz        Measure = "Hz"
+	MeasureMS        Measure = "ms"
+	MeasureBPM       Measure = "BPM"
+	MeasureDB        Measure = "dB"
+	MeasureGate      Measure = "gate"
+	MeasureTrigger   Measure = "trigger"
+	MeasureSemitones Measure = "semitones"
+	MeasureMode      Measure = "mode"
+	MeasureInteger   Measure = "integer"
+)
+
+// Meta is descriptive information about an input, output or property. Ranges are expressed in the same terms as the
+// Measure (e.g. Hz rather than the normalized frequency used internally).
+type Meta struct {
+	Measure  Measure
+	Min, Max float64
+	Doc      string
+
+	// Unsmoothed is set for inputs whose values are stepped between, which jump to patched constants rather than
+	// ramping to them.
+	Unsmoothed bool
+}
+
+// HasRange returns whether or not an expected range has been described.
+func (m Meta) HasRange() bool {
+	return m.Min != m.Max
+}
+
+// MetaOption sets a piece of descriptive information on an input, output or property.
+type MetaOption func(*Meta)
+
+// WithMeasure sets the unit of measure.
+func WithMeasure(measure Measure) MetaOption {
+	return func(m *Meta) {
+		m.Measure = measure
+	}
+}
+
+// WithRange sets the expected range of values.
+func WithRange(min, max float64) MetaOption {
+	return func(m *Meta) {
+		m.Min, m.Max = min, max
+	}
+}
+
+// WithoutSmoothing marks an input whose values are stepped between, such as the values of a sequencer's steps.
+func WithoutSmoothing() MetaOption {
+	return func(m *Meta) {
+		m.Unsmoothed = true
+	}
+}
+
+// WithDoc sets a one-line description.
+func WithDoc(doc string) MetaOption {
+	return func(m *Meta) {
+		m.Doc = doc
+	}
+}
+
+// newMeta builds a Meta for a value. The unit of measure is inferred from the type of the value when it's one of the
+// dsp value types; options can always override it.
+func newMeta(v any, opts []MetaOption) Meta {
+	var m Meta
+	switch v.(type) {
+	case dsp.Hz, dsp.Pitch:
+		m.Measure = MeasureHz
+	case dsp.MS:
+		m.Measure = MeasureMS
+	case dsp.BeatsPerMin:
+		m.Measure = MeasureBPM
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// Description is a self-description of a Unit's inputs, outputs and properties.
+type Description struct {
+	Type    string            `json:"type"`
+	Inputs  []PortDescription `json:"inputs"`
+	Outputs []PortDescription `json:"outputs"`
+	Props   []PortDescription `json:"props,omitempty"`
+}
+
+// PortDescription describes a single input, output or property.
+type PortDescription struct {
+	Name    string    `json:"name"`
+	Default string    `json:"default,omitempty"`
+	Measure Measure   `json:"measure,omitempty"`
+	Range   []float64 `json:"range,omitempty"`
+	Doc     string    `json:"doc,omitempty"`
+}
+
+func newPortDescription(name string, def any, m Meta) PortDescription {
+	d := PortDescription{
+		Name:    name,
+		Measure: m.Measure,
+		Doc:     m.Doc,
+	}
+	if def != nil {
+		d.Default = fmt.Sprint(def)
+	}
+	if m.HasRange() {
+		d.Range = []float64{m.Min, m.Max}
+	}
+	return d
+}
+
+// Describe returns a Description of all inputs, outputs and properties registered with the IO.
+func (io *IO) Describe() Description {
+	d := Description{Type: io.Type}
+	for name, in := range io.In {
+		d.Inputs = append(d.Inputs, newPortDescription(name, in.normal, in.meta))
+	}
+	for name, o := range io.Out {
+		d.Outputs = append(d.Outputs, newPortDescription(name, nil, o.Out().meta))
+	}
+	for name, p := range io.Prop {
+		d.Props = append(d.Props, newPortDescription(name, p.value, p.meta))
+	}
+	sortPorts(d.Inputs)
+	sortPorts(d.Outputs)
+	sortPorts(d.Props)
+	return d
+}
+
+func sortPorts(ports []PortDescription) {
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+}
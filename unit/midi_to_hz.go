@@ -8,8 +8,8 @@ import (
 
 func newMIDIToHz(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &midiToHz{
-		in:         io.NewIn("in", dsp.Float64(0)),
-		out:        io.NewOut("out"),
+		in:         io.NewIn("in", dsp.Float64(0), WithRange(0, 127), WithDoc("MIDI note number")),
+		out:        io.NewOut("out", WithMeasure(MeasureHz), WithDoc("frequency of the note")),
 		sampleRate: float64(c.SampleRate),
 	}), nil
 }
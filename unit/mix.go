@@ -29,14 +29,14 @@ func newMix(io *IO, c Config) (*Unit, error) {
 		levels = make([]*In, config.Size)
 	)
 	for i := 0; i < len(inputs); i++ {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d/in", i), dsp.Float64(0))
-		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d/in", i), dsp.Float64(0), WithDoc("signal to mix"))
+		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1), WithDoc("level of the channel"))
 	}
 
 	return NewUnit(io, &mix{
-		master: io.NewIn("master", dsp.Float64(1)),
-		mode:   io.NewIn("mode", dsp.Float64(0)),
-		out:    io.NewOut("out"),
+		master: io.NewIn("master", dsp.Float64(1), WithRange(0, 1), WithDoc("level of the whole mix")),
+		mode:   io.NewIn("mode", dsp.Float64(0), WithMeasure(MeasureMode), WithRange(0, 1), WithDoc("sum (0) or average (1)")),
+		out:    io.NewOut("out", WithDoc("mixed signal")),
 		inputs: inputs,
 		levels: levels,
 	}), nil
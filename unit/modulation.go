@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// modulationIO holds the inputs shared by the modulation effects: a mono input mixed with its modulated copies and sent
+// to a pair of outputs whose modulation is offset from one another.
+type modulationIO struct {
+	in, rate, depth, feedback, spread, mod, mix *In
+	aOut, bOut                                  *Out
+}
+
+func newModulationIO(io *IO, c Config, rate float64) modulationIO {
+	return modulationIO{
+		in:       io.NewIn("in", dsp.Float64(0), WithDoc("signal to modulate")),
+		rate:     io.NewIn("rate", dsp.Frequency(rate, c.SampleRate), WithRange(0, 20), WithDoc("rate of the internal LFO")),
+		depth:    io.NewIn("depth", dsp.Float64(0.5), WithRange(0, 1), WithDoc("amount of modulation")),
+		feedback: io.NewIn("feedback", dsp.Float64(0), WithRange(-0.95, 0.95), WithDoc("amount of the modulated signal fed back")),
+		spread:   io.NewIn("spread", dsp.Float64(0.5), WithRange(0, 1), WithDoc("phase offset between the modulation of a and b, up to half a cycle")),
+		mod:      io.NewIn("mod", dsp.Float64(0), WithRange(-1, 1), WithDoc("external modulation added to the internal LFO")),
+		mix:      io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between dry (-1) and wet (1)")),
+		aOut:     io.NewOut("a", WithDoc("left output")),
+		bOut:     io.NewOut("b", WithDoc("right output")),
+	}
+}
+
+// modulationSample is the state of the shared inputs at a sample.
+type modulationSample struct {
+	in, depth, feedback, mix float64
+	// offset is the phase of b's modulation relative to a's, in radians.
+	offset, mod float64
+}
+
+func (m modulationIO) read(i int) modulationSample {
+	return modulationSample{
+		in:       m.in.Read(i),
+		depth:    dsp.Clamp(m.depth.Read(i), 0, 1),
+		feedback: dsp.Clamp(m.feedback.Read(i), -0.95, 0.95),
+		mix:      m.mix.Read(i),
+		offset:   dsp.Clamp(m.spread.Read(i), 0, 1) * math.Pi,
+		mod:      m.mod.Read(i),
+	}
+}
+
+// lfo returns the modulation, in the range [-1, 1], at a phase of the internal LFO.
+func (s modulationSample) lfo(phase float64) float64 {
+	return dsp.Clamp(dsp.Sin(phase)+s.mod, -1, 1)
+}
+
+func (m modulationIO) write(i int, s modulationSample, a, b float64) {
+	m.aOut.Write(i, dsp.Mix(s.mix, s.in, a))
+	m.bOut.Write(i, dsp.Mix(s.mix, s.in, b))
+}
+
+// modulatedDelay is a delay line read at a delay that changes smoothly from sample to sample.
+type modulatedDelay struct {
+	dl *dsp.DelayLine
+}
+
+func newModulatedDelay(maxDelay float64) modulatedDelay {
+	return modulatedDelay{dl: dsp.NewDelayLine(int(math.Ceil(maxDelay)) + 2)}
+}
+
+func (d modulatedDelay) write(v float64) {
+	d.dl.Write(v)
+}
+
+// read returns the signal from delay samples ago, interpolating between samples. After a write, the sample at position
+// 1 is the one just written.
+func (d modulatedDelay) read(delay float64) float64 {
+	return d.dl.ReadAbsolute(1 + dsp.Clamp(delay, 0, float64(d.dl.Size()-2)))
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// runModulation feeds a signal, given per sample, to a modulation effect and returns n samples of each output.
+func runModulation(u *Unit, inputs map[string]float64, n int, signal func(int) float64) (a, b []float64) {
+	for len(a) < n {
+		for i := 0; i < frameSize; i++ {
+			u.In["in"].Write(i, signal(len(a)+i))
+			for name, v := range inputs {
+				u.In[name].Write(i, v)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			a = append(a, u.Out["a"].Out().Read(i))
+			b = append(b, u.Out["b"].Out().Read(i))
+		}
+	}
+	return a[:n], b[:n]
+}
+
+func impulse(n int) float64 {
+	if n == 0 {
+		return 1
+	}
+	return 0
+}
+
+func TestChorus(t *testing.T) {
+	// Without modulation, every voice is the input delayed.
+	u := newTestUnit(t, "chorus", nil)
+	a, b := runModulation(u, map[string]float64{
+		"mix":   1,
+		"depth": 0,
+		"delay": dsp.Duration(10, sampleRate).Float64(),
+	}, 1000, impulse)
+	for j := range a {
+		expected := 0.0
+		if j == 441 {
+			expected = 1
+		}
+		require.InDelta(t, expected, a[j], 1e-9, "sample %d", j)
+		require.InDelta(t, expected, b[j], 1e-9, "sample %d", j)
+	}
+
+	// Modulation offset between the outputs sets them apart.
+	u = newTestUnit(t, "chorus", nil)
+	sine := func(n int) float64 { return math.Sin(float64(n) * 0.05) }
+	a, b = runModulation(u, map[string]float64{"mix": 1, "depth": 1, "spread": 1}, 20000, sine)
+	var diff float64
+	for j := range a {
+		diff = math.Max(diff, math.Abs(a[j]-b[j]))
+	}
+	require.True(t, diff > 0.1)
+}
+
+func TestFlanger(t *testing.T) {
+	u := newTestUnit(t, "flanger", nil)
+	a, _ := runModulation(u, map[string]float64{
+		"mix":   1,
+		"depth": 0,
+		"delay": dsp.Duration(1, sampleRate).Float64(),
+	}, 100, impulse)
+	require.InDelta(t, 0.9, a[44], 1e-9)
+	require.InDelta(t, 0.1, a[45], 1e-9)
+
+	// Through zero, the dry signal is delayed to the middle of the sweep, which is where the swept copy sits without
+	// modulation.
+	u = newTestUnit(t, "flanger", map[string]any{"throughzero": true})
+	a, _ = runModulation(u, map[string]float64{"depth": 0}, 400, impulse)
+	require.InDelta(t, 1, a[220], 1e-9)
+	require.InDelta(t, 1, a[221], 1e-9)
+	require.InDelta(t, 0, a[222], 1e-9)
+}
+
+func TestPhaser(t *testing.T) {
+	freq := dsp.Frequency(800, sampleRate).Float64()
+	sine := func(n int) float64 { return math.Sin(2 * math.Pi * freq * float64(n)) }
+	peak := func(values map[string]any, mix float64) float64 {
+		u := newTestUnit(t, "phaser", values)
+		a, _ := runModulation(u, map[string]float64{"mix": mix, "depth": 0}, 10000, sine)
+		var p float64
+		for _, v := range a[5000:] {
+			p = math.Max(p, math.Abs(v))
+		}
+		return p
+	}
+
+	// The allpass chain passes every frequency at the same level.
+	require.InDelta(t, 1, peak(nil, 1), 1e-3)
+	// Two stages turn the center frequency around, which cancels it against the dry signal.
+	require.InDelta(t, 0, peak(map[string]any{"stages": 2}, 0), 1e-3)
+}
+
+func TestModulation_InvalidConfig(t *testing.T) {
+	_, err := Builders()["chorus"](Config{Values: map[string]any{"voices": 9}})
+	require.EqualError(t, err, "unit/chorus: voices must be between 1 and 8, got 9")
+
+	_, err = Builders()["phaser"](Config{Values: map[string]any{"stages": 3}})
+	require.EqualError(t, err, "unit/phaser: stages must be an even number between 2 and 12, got 3")
+}
@@ -21,12 +21,12 @@ func newMorph(io *IO, c Config) (*Unit, error) {
 
 	inputs := make([]*In, config.Size)
 	for i := 0; i < len(inputs); i++ {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0), WithDoc("signal to morph between"))
 	}
 
 	return NewUnit(io, &morph{
-		morph:  io.NewIn("morph", dsp.Float64(0)),
-		out:    io.NewOut("out"),
+		morph:  io.NewIn("morph", dsp.Float64(0), WithRange(0, 1), WithDoc("position across the inputs")),
+		out:    io.NewOut("out", WithDoc("interpolation of the neighboring inputs")),
 		inputs: inputs,
 	}), nil
 }
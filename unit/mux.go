@@ -20,12 +20,12 @@ func newMux(io *IO, c Config) (*Unit, error) {
 
 	inputs := make([]*In, config.Size)
 	for i := range inputs {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0), WithDoc("signal to select from"))
 	}
 
 	return NewUnit(io, &mux{
-		selection: io.NewIn("select", dsp.Float64(1)),
-		out:       io.NewOut("out"),
+		selection: io.NewIn("select", dsp.Float64(1), WithMeasure(MeasureInteger), WithDoc("index of the selected input")),
+		out:       io.NewOut("out", WithDoc("selected input")),
 		inputs:    inputs,
 	}), nil
 }
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const onsetFrameMS = 10 // shortest analysis frame; rounded up to a power of two
+
+// newOnsetDetector returns a detector whose frames are about onsetFrameMS long at a sample rate.
+func newOnsetDetector(sampleRate int) *dsp.OnsetDetector {
+	size := dsp.NextPowerOfTwo(int(dsp.Duration(onsetFrameMS, sampleRate).Float64()))
+	return dsp.NewOnsetDetector(size, size/4)
+}
+
+func newOnset(io *IO, c Config) (*Unit, error) {
+	return NewUnit(io, &onset{
+		in:          io.NewIn("in", dsp.Float64(0), WithDoc("signal whose onsets are detected")),
+		sensitivity: io.NewIn("sensitivity", dsp.Float64(0.5), WithRange(0, 1), WithDoc("higher values detect quieter onsets")),
+		holdoff:     io.NewIn("holdoff", dsp.Duration(50, c.SampleRate), WithRange(0, 1000), WithDoc("shortest time between onsets; onsets found sooner are ignored")),
+		length:      io.NewIn("length", dsp.Duration(10, c.SampleRate), WithRange(0, 1000), WithDoc("time the gate stays high after an onset")),
+		gate:        io.NewOut("gate", WithMeasure(MeasureGate), WithDoc("high for the gate length after each onset")),
+		trigger:     io.NewOut("trigger", WithMeasure(MeasureTrigger), WithDoc("high for a single sample at each onset")),
+		strength:    io.NewOut("strength", WithDoc("strength of the last onset")),
+		detector:    newOnsetDetector(c.SampleRate),
+		sinceOnset:  -1,
+	}), nil
+}
+
+// onset detects the onsets of notes and hits in its input. Detection trails each onset by up to a frame of analysis,
+// which is around 10-20ms.
+type onset struct {
+	in, sensitivity, holdoff, length *In
+	gate, trigger, strength          *Out
+
+	detector     *dsp.OnsetDetector
+	sinceOnset   int // samples since the last onset; negative before the first one
+	lastStrength float64
+}
+
+func (o *onset) ProcessSample(i int) {
+	var (
+		found, strength = o.detector.Tick(o.in.Read(i), o.sensitivity.Read(i))
+		held            = o.sinceOnset >= 0 && float64(o.sinceOnset) < o.holdoff.Read(i)
+		trigger         = -1.0
+	)
+	if o.sinceOnset >= 0 {
+		o.sinceOnset++
+	}
+	if found && !held {
+		o.sinceOnset = 0
+		o.lastStrength = strength
+		trigger = 1
+	}
+
+	gate := -1.0
+	if o.sinceOnset >= 0 && float64(o.sinceOnset) < o.length.Read(i) {
+		gate = 1
+	}
+	o.gate.Write(i, gate)
+	o.trigger.Write(i, trigger)
+	o.strength.Write(i, o.lastStrength)
+}
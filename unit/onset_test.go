@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+// drumHits returns n samples of decaying noise bursts starting at each of the hits.
+func drumHits(n int, hits ...int) []float64 {
+	var (
+		out  = make([]float64, n)
+		seed = uint32(1)
+	)
+	for _, h := range hits {
+		for j := h; j < n && j < h+4000; j++ {
+			seed = seed*1664525 + 1013904223
+			noise := float64(seed)/math.MaxUint32*2 - 1
+			out[j] += 0.8 * noise * math.Exp(-float64(j-h)/600)
+		}
+	}
+	return out
+}
+
+func TestOnset(t *testing.T) {
+	u, err := Builders()["onset"](Config{
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MeasureTrigger, u.Out["trigger"].Out().Meta().Measure)
+
+	var (
+		hits     = []int{4000, 12000, 20000}
+		signal   = drumHits(24064, hits...)
+		triggers []int
+		gateLow  = true
+	)
+	for n := 0; n+frameSize <= len(signal); n += frameSize {
+		for i := 0; i < frameSize; i++ {
+			u.In["in"].Write(i, signal[n+i])
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			if u.Out["trigger"].Out().Read(i) > 0 {
+				triggers = append(triggers, n+i)
+				require.Equal(t, 1.0, u.Out["gate"].Out().Read(i))
+				require.True(t, u.Out["strength"].Out().Read(i) > 0)
+			}
+			if n+i == 3000 {
+				gateLow = u.Out["gate"].Out().Read(i) < 0
+			}
+		}
+	}
+	require.True(t, gateLow)
+	require.Len(t, triggers, len(hits))
+	for i, h := range hits {
+		require.True(t, triggers[i] >= h && triggers[i] < h+1024, "trigger %d at %d", i, triggers[i])
+	}
+}
+
+func TestOnset_Holdoff(t *testing.T) {
+	u, err := Builders()["onset"](Config{
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	// Hits about 57ms apart, held off for 100ms: only every other hit triggers.
+	var (
+		signal   = drumHits(22050, 2000, 4500, 7000, 9500)
+		triggers int
+	)
+	for n := 0; n+frameSize <= len(signal); n += frameSize {
+		for i := 0; i < frameSize; i++ {
+			u.In["in"].Write(i, signal[n+i])
+			u.In["holdoff"].Write(i, 0.1*sampleRate)
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			if u.Out["trigger"].Out().Read(i) > 0 {
+				triggers++
+			}
+		}
+	}
+	require.Equal(t, 2, triggers)
+}
@@ -25,13 +25,18 @@ type Out struct {
 	unit  *Unit
 	node  *graph.Node
 	frame []float64
+	meta  Meta
+
+	// last is the most recent value written at control rate.
+	last float64
 }
 
 // NewOut returns a new output
-func NewOut(name string, f []float64) *Out {
+func NewOut(name string, f []float64, opts ...MetaOption) *Out {
 	return &Out{
 		name:  name,
 		frame: f,
+		meta:  newMeta(nil, opts),
 	}
 }
 
@@ -42,9 +47,17 @@ func (out *Out) Out() *Out {
 
 // Rate returns the rate of the parent Unit
 func (out *Out) Rate() Rate {
+	if out.unit == nil {
+		return RateAudio
+	}
 	return out.unit.rate
 }
 
+// Meta returns the descriptive information for this output.
+func (out *Out) Meta() Meta {
+	return out.meta
+}
+
 // DestinationCount returns the number of outbound connections to this output
 func (out *Out) DestinationCount() int {
 	return out.node.OutNeighborCount()
@@ -60,6 +73,42 @@ func (out *Out) Read(i int) float64 {
 	return out.frame[i]
 }
 
+// expand spreads a value written at control rate, in the first sample of the frame, across the rest of the frame for
+// audio rate consumers. Values are interpolated from the previous control rate value; gates and triggers are held.
+func (out *Out) expand(n int) {
+	if n > len(out.frame) {
+		n = len(out.frame)
+	}
+	cur := out.frame[0]
+	switch out.meta.Measure {
+	case MeasureGate, MeasureTrigger:
+		for i := 1; i < n; i++ {
+			out.frame[i] = cur
+		}
+	default:
+		step := (cur - out.last) / float64(n)
+		for i := 0; i < n; i++ {
+			out.frame[i] = out.last + step*float64(i+1)
+		}
+	}
+	out.last = cur
+}
+
+// ProcessOutputFrame processes a frame of an output processor at the rate of its Unit: once per frame at control rate,
+// otherwise once per sample. It's intended to be called from the ProcessFrame method of output processors that also
+// implement ControlProcessor.
+func ProcessOutputFrame(o OutputProcessor, n int) {
+	out := o.Out()
+	if cp, ok := o.(ControlProcessor); ok && out.Rate() == RateControl {
+		cp.ProcessControl(n)
+		out.expand(n)
+		return
+	}
+	for i := 0; i < n; i++ {
+		o.ProcessSample(i)
+	}
+}
+
 // ExternalNeighborCount returns the count of neighboring nodes outside of the parent Unit
 func (out *Out) ExternalNeighborCount() int {
 	return out.node.OutNeighborCount()
@@ -4,9 +4,9 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newOverload(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &overload{
-		in:   io.NewIn("in", dsp.Float64(0)),
-		gain: io.NewIn("gain", dsp.Float64(1)),
-		out:  io.NewOut("out"),
+		in:   io.NewIn("in", dsp.Float64(0), WithDoc("signal to saturate")),
+		gain: io.NewIn("gain", dsp.Float64(1), WithDoc("gain applied before saturation")),
+		out:  io.NewOut("out", WithDoc("saturated signal")),
 	}), nil
 }
 
@@ -4,10 +4,10 @@ import "github.com/brettbuddin/shaden/dsp"
 
 func newPan(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &pan{
-		in:  io.NewIn("in", dsp.Float64(0)),
-		pan: io.NewIn("pan", dsp.Float64(0)),
-		a:   io.NewOut("a"),
-		b:   io.NewOut("b"),
+		in:  io.NewIn("in", dsp.Float64(0), WithDoc("signal to pan")),
+		pan: io.NewIn("pan", dsp.Float64(0), WithRange(-1, 1), WithDoc("position between a (-1) and b (1)")),
+		a:   io.NewOut("a", WithDoc("left side")),
+		b:   io.NewOut("b", WithDoc("right side")),
 	}), nil
 }
 
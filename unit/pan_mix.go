@@ -25,16 +25,16 @@ func newPanMix(io *IO, c Config) (*Unit, error) {
 		pans   = make([]*In, config.Size)
 	)
 	for i := 0; i < len(inputs); i++ {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d/in", i), dsp.Float64(0))
-		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1))
-		pans[i] = io.NewIn(fmt.Sprintf("%d/pan", i), dsp.Float64(0))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d/in", i), dsp.Float64(0), WithDoc("signal to mix"))
+		levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1), WithDoc("level of the channel"))
+		pans[i] = io.NewIn(fmt.Sprintf("%d/pan", i), dsp.Float64(0), WithRange(-1, 1), WithDoc("position of the channel between a (-1) and b (1)"))
 	}
 
 	return NewUnit(io, &panMix{
-		master: io.NewIn("master", dsp.Float64(1)),
-		mode:   io.NewIn("mode", dsp.Float64(0)),
-		a:      io.NewOut("a"),
-		b:      io.NewOut("b"),
+		master: io.NewIn("master", dsp.Float64(1), WithRange(0, 1), WithDoc("level of the whole mix")),
+		mode:   io.NewIn("mode", dsp.Float64(0), WithMeasure(MeasureMode), WithRange(0, 1), WithDoc("sum (0) or average (1)")),
+		a:      io.NewOut("a", WithDoc("left side of the mix")),
+		b:      io.NewOut("b", WithDoc("right side of the mix")),
 		inputs: inputs,
 		levels: levels,
 		pans:   pans,
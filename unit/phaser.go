@@ -0,0 +1,68 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultPhaserStages = 4
+	maxPhaserStages     = 12
+	phaserOctaves       = 3 // octaves swept either side of the center frequency at full depth
+)
+
+func newPhaser(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Stages int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Stages == 0 {
+		config.Stages = defaultPhaserStages
+	} else if config.Stages < 2 || config.Stages > maxPhaserStages || config.Stages%2 != 0 {
+		return nil, c.errorf("stages must be an even number between 2 and %d, got %d", maxPhaserStages, config.Stages)
+	}
+
+	return NewUnit(io, &phaser{
+		modulationIO: newModulationIO(io, c, 0.3),
+		freq:         io.NewIn("freq", dsp.Frequency(800, c.SampleRate), WithRange(0, float64(c.SampleRate)/2), WithDoc("frequency at the center of the sweep")),
+		aStages:      make([]dsp.FirstOrderAllPass, config.Stages),
+		bStages:      make([]dsp.FirstOrderAllPass, config.Stages),
+	}), nil
+}
+
+// phaser mixes its input with a copy of it passed through a chain of allpass filters, whose break frequencies are swept
+// by an LFO. Each pair of stages puts a notch where the copy's phase is opposite to the input's.
+type phaser struct {
+	modulationIO
+	freq *In
+
+	aStages, bStages []dsp.FirstOrderAllPass
+	aLast, bLast     float64
+	phase            float64
+}
+
+func (p *phaser) ProcessSample(i int) {
+	var (
+		s     = p.read(i)
+		freq  = p.freq.Read(i)
+		sweep = s.depth * phaserOctaves
+		aCoef = dsp.AllPassCoefficient(freq * math.Exp2(sweep*s.lfo(p.phase)))
+		bCoef = dsp.AllPassCoefficient(freq * math.Exp2(sweep*s.lfo(p.phase+s.offset)))
+	)
+	a := s.in + p.aLast*s.feedback
+	for j := range p.aStages {
+		a = p.aStages[j].Tick(a, aCoef)
+	}
+	b := s.in + p.bLast*s.feedback
+	for j := range p.bStages {
+		b = p.bStages[j].Tick(b, bCoef)
+	}
+	p.aLast, p.bLast = a, b
+	advanceLFO(&p.phase, math.Max(p.rate.Read(i), 0))
+
+	p.write(i, s, a, b)
+}
@@ -18,9 +18,9 @@ func newPitch(io *IO, c Config) (*Unit, error) {
 
 	return NewUnit(io, &pitch{
 		pitches: pitches,
-		class:   io.NewIn("class", dsp.Float64(0)),
-		octave:  io.NewIn("octave", dsp.Float64(4)),
-		out:     io.NewOut("out"),
+		class:   io.NewIn("class", dsp.Float64(0), WithRange(0, 11), WithDoc("pitch class where 0 is C")),
+		octave:  io.NewIn("octave", dsp.Float64(4), WithRange(0, 8), WithDoc("octave of the pitch")),
+		out:     io.NewOut("out", WithMeasure(MeasureHz), WithDoc("frequency of the pitch")),
 	}), nil
 }
 
@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	pitchTrackLowest  = 40.0   // Hz
+	pitchTrackHighest = 2000.0 // Hz
+	pitchTrackHopMS   = 5      // time between analyses
+)
+
+func newPitchTrack(io *IO, c Config) (*Unit, error) {
+	var (
+		detector = dsp.NewPitchDetector(
+			int(float64(c.SampleRate)/pitchTrackHighest),
+			int(float64(c.SampleRate)/pitchTrackLowest)+1,
+		)
+		hop = int(dsp.Duration(pitchTrackHopMS, c.SampleRate).Float64())
+	)
+	return NewUnit(io, &pitchTrack{
+		in:            io.NewIn("in", dsp.Float64(0), WithDoc("monophonic signal to track")),
+		threshold:     io.NewIn("threshold", dsp.Float64(0.15), WithRange(0.01, 1), WithDoc("how dissimilar repeats can be while still taken as the period; lower values mistake harmonics for the pitch less often")),
+		minConfidence: io.NewIn("confidence", dsp.Float64(0.8), WithRange(0, 1), WithDoc("confidence needed to raise the gate and update freq")),
+		freq:          io.NewOut("freq", WithMeasure(MeasureHz), WithDoc("frequency of the last confidently tracked pitch")),
+		confidence:    io.NewOut("confidence", WithDoc("how clearly the input repeats at the tracked pitch, from 0 to 1")),
+		gate:          io.NewOut("gate", WithMeasure(MeasureGate), WithDoc("high while the pitch is tracked confidently")),
+		detector:      detector,
+		history:       make([]float64, detector.Size()),
+		window:        make([]float64, detector.Size()),
+		hop:           max(hop, 1),
+	}), nil
+}
+
+// pitchTrack tracks the pitch of its input, analyzing the most recent stretch of it every few milliseconds. The pitch
+// holds while the input is silent or unpitched, so that it can keep driving oscillators between notes.
+type pitchTrack struct {
+	in, threshold, minConfidence *In
+	freq, confidence, gate       *Out
+
+	detector        *dsp.PitchDetector
+	history, window []float64
+	pos, count, hop int
+
+	lastFreq, lastConfidence, lastGate float64
+}
+
+func (p *pitchTrack) ProcessSample(i int) {
+	p.history[p.pos] = p.in.Read(i)
+	p.pos = (p.pos + 1) % len(p.history)
+	p.count++
+	if p.count >= p.hop {
+		p.count = 0
+		p.analyze(p.threshold.Read(i), p.minConfidence.Read(i))
+	}
+	p.freq.Write(i, p.lastFreq)
+	p.confidence.Write(i, p.lastConfidence)
+	p.gate.Write(i, p.lastGate)
+}
+
+func (p *pitchTrack) analyze(threshold, minConfidence float64) {
+	n := copy(p.window, p.history[p.pos:])
+	copy(p.window[n:], p.history[:p.pos])
+
+	period, confidence := p.detector.Detect(p.window, dsp.Clamp(threshold, 0.01, 1))
+	p.lastConfidence = confidence
+	p.lastGate = -1
+	if confidence > 0 && confidence >= minConfidence {
+		p.lastFreq = 1 / period
+		p.lastGate = 1
+	}
+}
@@ -0,0 +1,46 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+func TestPitchTrack(t *testing.T) {
+	u, err := Builders()["pitch-track"](Config{SampleRate: sampleRate, FrameSize: frameSize})
+	require.NoError(t, err)
+
+	var (
+		freq       = u.Out["freq"].Out()
+		confidence = u.Out["confidence"].Out()
+		gate       = u.Out["gate"].Out()
+		n          int
+	)
+	run := func(hz float64, frames int) {
+		f := dsp.Frequency(hz, sampleRate).Float64()
+		for j := 0; j < frames; j++ {
+			for i := 0; i < frameSize; i++ {
+				phase := 2 * math.Pi * f * float64(n)
+				u.In["in"].Write(i, 0.5*math.Sin(phase)+0.2*math.Sin(2*phase))
+				n++
+			}
+			u.ProcessFrame(frameSize)
+		}
+	}
+
+	for _, hz := range []float64{82.41, 196, 440, 987.77} {
+		run(hz, 40)
+		require.InEpsilon(t, dsp.Frequency(hz, sampleRate).Float64(), freq.Read(frameSize-1), 0.002, "%f Hz", hz)
+		require.True(t, confidence.Read(frameSize-1) > 0.9)
+		require.Equal(t, 1.0, gate.Read(frameSize-1))
+	}
+
+	// Silence closes the gate and holds the last pitch.
+	run(0, 40)
+	require.Equal(t, -1.0, gate.Read(frameSize-1))
+	require.Equal(t, 0.0, confidence.Read(frameSize-1))
+	require.InEpsilon(t, dsp.Frequency(987.77, sampleRate).Float64(), freq.Read(frameSize-1), 0.002)
+}
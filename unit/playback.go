@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// playhead plays back a region of an interleaved, multi-channel buffer. It moves at fractional speeds (negative
+// speeds play in reverse), interpolates between frames and, when looping, crossfades the end of the region into its
+// beginning so the seam doesn't click.
+type playhead struct {
+	data     []float64
+	channels int
+	pos      float64
+	playing  bool
+}
+
+// frames returns the number of frames in the buffer.
+func (p *playhead) frames() int {
+	if p.channels == 0 {
+		return 0
+	}
+	return len(p.data) / p.channels
+}
+
+// region converts a normalized region [0, 1] of the buffer to frame positions. The region is never empty.
+func (p *playhead) region(begin, end float64) (float64, float64) {
+	return playbackRegion(begin, end, p.frames())
+}
+
+// playbackRegion converts a normalized region [0, 1] of audio that's a number of frames long to frame positions.
+func playbackRegion(begin, end float64, frames int) (float64, float64) {
+	length := float64(frames - 1)
+	return math.Min(end, dsp.Clamp(begin, 0, 0.95)) * length, math.Max(begin, dsp.Clamp(end, 0.05, 1)) * length
+}
+
+// start begins playback at the beginning of a region, or its end when playing in reverse.
+func (p *playhead) start(begin, end, speed float64) {
+	if speed < 0 {
+		p.pos = end
+	} else {
+		p.pos = begin
+	}
+	p.playing = true
+}
+
+// tick writes the current frame of each channel to out and advances the playhead by speed frames. The region is
+// described in frames; xfade is the length of the loop crossfade in frames. Out is zeroed when nothing is playing.
+func (p *playhead) tick(out []float64, begin, end, speed, xfade float64, loop bool) {
+	for c := range out {
+		out[c] = 0
+	}
+	if !p.playing {
+		return
+	}
+
+	var (
+		length = end - begin
+		fade   = math.Min(math.Max(xfade, 0), length/2)
+		jump   = length - fade // distance travelled by each wrap of a loop
+	)
+
+	// Within the crossfade the output blends towards the material the playhead will continue with after it wraps.
+	var mix, other float64
+	if loop && fade > 0 {
+		if speed >= 0 && p.pos > end-fade {
+			mix, other = (p.pos-(end-fade))/fade, p.pos-jump
+		} else if speed < 0 && p.pos < begin+fade {
+			mix, other = (begin+fade-p.pos)/fade, p.pos+jump
+		}
+	}
+	for c := range out {
+		if c >= p.channels {
+			break
+		}
+		out[c] = p.at(p.pos, c)
+		if mix > 0 {
+			out[c] = out[c]*math.Cos(mix*math.Pi/2) + p.at(other, c)*math.Sin(mix*math.Pi/2)
+		}
+	}
+
+	p.pos += speed
+	switch {
+	case speed >= 0 && p.pos > end:
+		if !loop {
+			p.playing = false
+			return
+		}
+		if fade > 0 {
+			p.pos -= jump
+		} else {
+			p.pos = begin + math.Mod(p.pos-end, math.Max(length, 1))
+		}
+	case speed < 0 && p.pos < begin:
+		if !loop {
+			p.playing = false
+			return
+		}
+		if fade > 0 {
+			p.pos += jump
+		} else {
+			p.pos = end - math.Mod(begin-p.pos, math.Max(length, 1))
+		}
+	}
+	p.pos = dsp.Clamp(p.pos, begin, end)
+}
+
+// at returns the value of a channel at a fractional frame position using Hermite interpolation. Frames beyond the
+// edges of the buffer repeat the edge frames.
+func (p *playhead) at(pos float64, channel int) float64 {
+	var (
+		last = p.frames() - 1
+		i    = int(math.Floor(pos))
+		frac = pos - float64(i)
+	)
+	frame := func(j int) float64 {
+		if j < 0 {
+			j = 0
+		} else if j > last {
+			j = last
+		}
+		return p.data[j*p.channels+channel]
+	}
+	return dsp.Hermite(frame(i-1), frame(i), frame(i+1), frame(i+2), frac)
+}
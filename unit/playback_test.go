@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRampPlayhead(frames int) *playhead {
+	data := make([]float64, frames)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	return &playhead{data: data, channels: 1}
+}
+
+func TestPlayhead_FractionalSpeed(t *testing.T) {
+	p := newRampPlayhead(100)
+	out := make([]float64, 1)
+
+	p.start(10, 20, 0.5)
+	for i := 0; i < 20; i++ {
+		p.tick(out, 10, 20, 0.5, 0, false)
+		require.InDelta(t, 10+0.5*float64(i), out[0], 1e-9)
+	}
+	p.tick(out, 10, 20, 0.5, 0, false)
+	require.Equal(t, 20.0, out[0])
+	p.tick(out, 10, 20, 0.5, 0, false)
+	require.False(t, p.playing)
+	require.Equal(t, 0.0, out[0])
+}
+
+func TestPlayhead_Reverse(t *testing.T) {
+	p := newRampPlayhead(100)
+	out := make([]float64, 1)
+
+	p.start(10, 20, -1)
+	for i := 0; i <= 10; i++ {
+		p.tick(out, 10, 20, -1, 0, true)
+		require.Equal(t, 20-float64(i), out[0])
+	}
+	p.tick(out, 10, 20, -1, 0, true)
+	require.True(t, p.playing)
+	require.Equal(t, 19.0, out[0])
+}
+
+func TestPlayhead_LoopCrossfade(t *testing.T) {
+	// A sine with a non-integer number of cycles in the region, so looping it without a crossfade makes a jump.
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = math.Sin(2 * math.Pi * float64(i) / 70)
+	}
+	maxStep := func(xfade float64) float64 {
+		p := &playhead{data: data, channels: 1}
+		out := make([]float64, 1)
+		p.start(0, 999, 1)
+		p.tick(out, 0, 999, 1, xfade, true)
+		var (
+			last = out[0]
+			max  float64
+		)
+		for i := 0; i < 3000; i++ {
+			p.tick(out, 0, 999, 1, xfade, true)
+			max = math.Max(max, math.Abs(out[0]-last))
+			last = out[0]
+		}
+		return max
+	}
+
+	// The largest step of the sine itself is about 2*pi/70; an equal-power crossfade can raise it by up to sqrt(2).
+	require.True(t, maxStep(0) > 0.5)
+	require.True(t, maxStep(100) < 0.15)
+}
@@ -0,0 +1,87 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+func newPluck(io *IO, c Config) (*Unit, error) {
+	size := waveguideSize(c.SampleRate)
+	return NewUnit(io, &pluck{
+		rand:        c.Rand,
+		in:          io.NewIn("in", dsp.Float64(0), WithDoc("signal that excites the string")),
+		pitch:       io.NewIn("pitch", dsp.Frequency(220, c.SampleRate), WithDoc("frequency of the string")),
+		trigger:     io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("plucks the string")),
+		level:       io.NewIn("level", dsp.Float64(1), WithRange(0, 1), WithDoc("strength of the pluck")),
+		damping:     io.NewIn("damping", dsp.Float64(0.3), WithRange(0, 1), WithDoc("how quickly the string decays")),
+		brightness:  io.NewIn("brightness", dsp.Float64(0.5), WithRange(0, 1), WithDoc("how much of the high end of the pluck lasts")),
+		position:    io.NewIn("position", dsp.Float64(0.2), WithRange(0, 1), WithDoc("where along the string it's plucked")),
+		out:         io.NewOut("out", WithDoc("vibration of the string")),
+		str:         newWaveguideDelay(size),
+		burst:       dsp.NewDelayLine(size),
+		block:       &dsp.DCBlock{},
+		size:        size,
+		sampleRate:  float64(c.SampleRate),
+		lastTrigger: -1,
+	}), nil
+}
+
+// pluck is a Karplus-Strong string. A trigger excites it with a burst of noise one period long, which is colored by
+// the brightness and combed to mimic where the string is plucked. The string can also be excited by any signal patched
+// to its input.
+type pluck struct {
+	rand                                *rand.Rand
+	in, pitch, trigger, level           *In
+	damping, brightness, position       *In
+	out                                 *Out
+	str                                 *waveguideDelay
+	loss                                waveguideLoss
+	burst                               *dsp.DelayLine
+	block                               *dsp.DCBlock
+	size                                int
+	sampleRate                          float64
+	lastTrigger, last, noise, remaining float64
+	amplitude                           float64
+}
+
+func (p *pluck) ProcessSample(i int) {
+	var (
+		period     = waveguidePeriod(p.pitch.Read(i), p.size)
+		brightness = p.brightness.Read(i)
+		weight     = waveguideWeight(brightness)
+		trigger    = p.trigger.Read(i)
+	)
+	if isTrig(p.lastTrigger, trigger) {
+		p.remaining = math.Round(period)
+		p.amplitude = p.level.Read(i)
+	}
+	p.lastTrigger = trigger
+
+	excite := p.in.Read(i) + p.excite(i, period, brightness)
+	feedback := p.loss.tick(p.last, weight, waveguideGain(p.damping.Read(i), period, p.sampleRate))
+	// The loop is one sample longer than the string, as feedback is taken from the previous sample.
+	p.last = p.str.tick(excite+feedback, period-weight-1)
+	p.out.Write(i, p.block.Tick(p.last))
+}
+
+// excite returns the next sample of the noise burst. Subtracting the burst from a delayed copy of itself notches out
+// the harmonics that have a node at the pluck position.
+func (p *pluck) excite(i int, period, brightness float64) float64 {
+	var noise float64
+	if p.remaining > 0 {
+		p.remaining--
+		// Darker plucks are smoothed more heavily.
+		p.noise += (p.rand.Float64()*2 - 1 - p.noise) * (0.05 + 0.95*dsp.Clamp(brightness, 0, 1))
+		noise = p.noise * p.amplitude
+	}
+	offset := dsp.Clamp(p.position.Read(i), 0, 1) * period
+	delayed := p.burst.ReadAbsolute(math.Max(1, offset))
+	p.burst.Write(noise)
+	if offset < 1 {
+		// Plucking at the very end of the string would cancel the burst out entirely.
+		return noise
+	}
+	return noise - delayed
+}
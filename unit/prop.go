@@ -15,6 +15,7 @@ type Prop struct {
 	name   string
 	setter PropSetterFunc
 	value  any
+	meta   Meta
 }
 
 // Value returns the Prop's value
@@ -22,6 +23,11 @@ func (p *Prop) Value() any {
 	return p.value
 }
 
+// Meta returns the descriptive information for this Prop.
+func (p *Prop) Meta() Meta {
+	return p.meta
+}
+
 // SetValue sets the Prop's value using its internal PropSetterFunc (if it has one)
 func (p *Prop) SetValue(v any) error {
 	if p.setter == nil {
@@ -3,6 +3,7 @@ package unit
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/brettbuddin/musictheory"
 	"github.com/brettbuddin/shaden/dsp"
@@ -17,6 +18,14 @@ type intervals struct {
 	sig       string
 }
 
+func (i *intervals) String() string {
+	names := make([]string, len(i.intervals))
+	for j, intvl := range i.intervals {
+		names[j] = intvl.String()
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
 func intervalSetter(p *Prop, v any) error {
 	slice, ok := v.([]any)
 	if !ok {
@@ -43,10 +52,10 @@ func newQuantize(io *IO, c Config) (*Unit, error) {
 	}
 
 	q := &quantize{
-		intervals: io.NewProp("intervals", &intervals{}, intervalSetter),
-		in:        io.NewIn("in", dsp.Float64(0)),
-		tonic:     io.NewIn("tonic", tonic),
-		out:       io.NewOut("out"),
+		intervals: io.NewProp("intervals", &intervals{}, intervalSetter, WithDoc("intervals of the scale")),
+		in:        io.NewIn("in", dsp.Float64(0), WithRange(0, 1), WithDoc("position within the scale")),
+		tonic:     io.NewIn("tonic", tonic, WithDoc("root of the scale")),
+		out:       io.NewOut("out", WithMeasure(MeasureHz), WithDoc("quantized frequency")),
 		ratios:    make([]float64, maxIntervals),
 	}
 	q.maybeUpdate()
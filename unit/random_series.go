@@ -14,15 +14,15 @@ func newRandomSeries(io *IO, c Config) (*Unit, error) {
 
 	return NewUnit(io, &randomSeries{
 		rand:      c.Rand,
-		clock:     io.NewIn("clock", dsp.Float64(-1)),
-		length:    io.NewIn("length", dsp.Float64(8)),
-		lock:      io.NewIn("lock", dsp.Float64(0)),
-		min:       io.NewIn("min", dsp.Float64(0)),
-		max:       io.NewIn("max", dsp.Float64(1)),
+		clock:     io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("advances the series")),
+		length:    io.NewIn("length", dsp.Float64(8), WithRange(2, 16), WithDoc("number of steps in the series")),
+		lock:      io.NewIn("lock", dsp.Float64(0), WithRange(0, 1), WithDoc("chance a step is kept rather than replaced")),
+		min:       io.NewIn("min", dsp.Float64(0), WithDoc("smallest value generated")),
+		max:       io.NewIn("max", dsp.Float64(1), WithDoc("largest value generated")),
 		gates:     gates,
 		values:    make([]float64, 16),
-		gate:      io.NewOut("gate"),
-		value:     io.NewOut("value"),
+		gate:      io.NewOut("gate", WithMeasure(MeasureGate), WithDoc("gate of the current step")),
+		value:     io.NewOut("value", WithDoc("value of the current step")),
 		lastClock: -1,
 	}), nil
 }
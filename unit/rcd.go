@@ -19,12 +19,12 @@ func newRCD(io *IO, c Config) (*Unit, error) {
 	}
 	var outs []*Out
 	for i := 1; i <= config.Size; i++ {
-		outs = append(outs, io.NewOut(fmt.Sprintf("%d", i)))
+		outs = append(outs, io.NewOut(fmt.Sprintf("%d", i), WithMeasure(MeasureGate), WithDoc("clock divided by this output's position")))
 	}
 	return NewUnit(io, &rcd{
-		clock:  io.NewIn("clock", dsp.Float64(-1)),
-		rotate: io.NewIn("rotate", dsp.Float64(-1)),
-		reset:  io.NewIn("reset", dsp.Float64(-1)),
+		clock:  io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("clock to divide")),
+		rotate: io.NewIn("rotate", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("rotates the divisions across the outputs")),
+		reset:  io.NewIn("reset", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("clears the rotation")),
 		ticks:  make([]int, config.Size),
 		outs:   outs,
 	}), nil
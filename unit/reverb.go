@@ -9,17 +9,17 @@ func newReverb(io *IO, c Config) (*Unit, error) {
 		aTravelFreq = dsp.Frequency(0.5, c.SampleRate).Float64()
 		bTravelFreq = dsp.Frequency(0.3, c.SampleRate).Float64()
 		r           = &reverb{
-			a:              io.NewIn("a", dsp.Float64(0)),
-			b:              io.NewIn("b", dsp.Float64(0)),
-			defuse:         io.NewIn("defuse", dsp.Float64(0.625)),
-			mix:            io.NewIn("mix", dsp.Float64(0)),
-			precutoff:      io.NewIn("cutoff-pre", dsp.Frequency(300, c.SampleRate)),
-			postcutoff:     io.NewIn("cutoff-post", dsp.Frequency(500, c.SampleRate)),
-			decay:          io.NewIn("decay", dsp.Float64(0.7)),
-			size:           io.NewIn("size", dsp.Float64(0.1)),
-			shiftSemitones: io.NewIn("shift-semitones", dsp.Float64(0)),
-			aOut:           io.NewOut("a"),
-			bOut:           io.NewOut("b"),
+			a:              io.NewIn("a", dsp.Float64(0), WithDoc("left input")),
+			b:              io.NewIn("b", dsp.Float64(0), WithDoc("right input")),
+			defuse:         io.NewIn("defuse", dsp.Float64(0.625), WithRange(0.4, 0.625), WithDoc("diffusion of the allpass network")),
+			mix:            io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between dry (-1) and wet (1)")),
+			precutoff:      io.NewIn("cutoff-pre", dsp.Frequency(300, c.SampleRate), WithDoc("cutoff of the filter before the tank")),
+			postcutoff:     io.NewIn("cutoff-post", dsp.Frequency(500, c.SampleRate), WithDoc("cutoff of the filter after the tank")),
+			decay:          io.NewIn("decay", dsp.Float64(0.7), WithRange(0, 0.99), WithDoc("length of the tail")),
+			size:           io.NewIn("size", dsp.Float64(0.1), WithRange(0.01, 1), WithDoc("size of the room")),
+			shiftSemitones: io.NewIn("shift-semitones", dsp.Float64(0), WithMeasure(MeasureSemitones), WithRange(-12, 12), WithDoc("pitch shift applied in the feedback path")),
+			aOut:           io.NewOut("a", WithDoc("left output")),
+			bOut:           io.NewOut("b", WithDoc("right output")),
 
 			ap:          make([]*dsp.AllPass, 4),
 			aAP:         make([]*dsp.AllPass, 2),
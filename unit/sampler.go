@@ -0,0 +1,292 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const defaultSamplerVoices = 16
+
+func newSampler(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		File   string
+		Voices int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.File == "" {
+		return nil, c.errorf("no file specified")
+	}
+	if config.Voices == 0 {
+		config.Voices = defaultSamplerVoices
+	}
+	if config.Voices < 0 {
+		return nil, c.errorf("voices must be positive; got %d", config.Voices)
+	}
+
+	regions, err := parseSFZ(config.File)
+	if err != nil {
+		return nil, c.errorf("%s", err)
+	}
+	if len(regions) == 0 {
+		return nil, c.errorf("%q contains no regions", config.File)
+	}
+
+	var (
+		s = &sampler{
+			rand:       c.Rand,
+			pitch:      io.NewIn("pitch", dsp.Frequency(261.63, c.SampleRate), WithDoc("frequency of the note to play")),
+			gate:       io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("plays a note when going high; releases it when going low")),
+			velocity:   io.NewIn("velocity", dsp.Float64(1), WithRange(0, 1), WithDoc("velocity of the note")),
+			a:          io.NewOut("a", WithDoc("left channel")),
+			b:          io.NewOut("b", WithDoc("right channel")),
+			regions:    make([]samplerRegion, len(regions)),
+			voices:     make([]samplerVoice, config.Voices),
+			sampleRate: float64(c.SampleRate),
+			lastGate:   -1,
+		}
+		buffers = map[string]*Buffer{}
+	)
+	for j, r := range regions {
+		buf, ok := buffers[r.sample]
+		if !ok {
+			if buf, err = LoadBuffer(r.sample); err != nil {
+				return nil, c.errorf("%s", err)
+			}
+			if buf.Frames() == 0 {
+				return nil, c.errorf("%q contains no samples", r.sample)
+			}
+			buffers[r.sample] = buf
+		}
+		s.regions[j] = newSamplerRegion(r, buf, c.SampleRate)
+	}
+
+	return NewUnit(io, s), nil
+}
+
+// samplerRegion is an SFZ region with its sample loaded and its playback settings resolved to frames and gains.
+type samplerRegion struct {
+	*sfzRegion
+	data                []float64
+	channels            int
+	rateMult            float64
+	end                 float64
+	loopStart, loopEnd  float64
+	leftGain, rightGain float64
+	sequence            int
+}
+
+func newSamplerRegion(r *sfzRegion, buf *Buffer, sampleRate int) samplerRegion {
+	var (
+		last      = float64(buf.Frames() - 1)
+		end       = last
+		loopStart = 0.0
+		loopEnd   = last
+		gain      = math.Pow(10, r.volume/20)
+		pan       = dsp.Clamp(r.pan/100, -1, 1)
+	)
+	if r.end >= 0 {
+		end = math.Min(float64(r.end), last)
+	}
+	if r.loopStart >= 0 {
+		loopStart = math.Min(float64(r.loopStart), last)
+	}
+	if r.loopEnd >= 0 {
+		loopEnd = math.Min(float64(r.loopEnd), last)
+	}
+	if loopEnd <= loopStart {
+		loopStart, loopEnd = 0, last
+	}
+	return samplerRegion{
+		sfzRegion: r,
+		data:      buf.Data,
+		channels:  buf.Channels,
+		rateMult:  sampleRateRatio(buf.SampleRate, sampleRate),
+		end:       end,
+		loopStart: loopStart,
+		loopEnd:   loopEnd,
+		leftGain:  gain * math.Min(1, 1-pan),
+		rightGain: gain * math.Min(1, 1+pan),
+	}
+}
+
+// velocityGain returns the gain applied to a note played at a velocity, following amp_veltrack.
+func (r *samplerRegion) velocityGain(vel int) float64 {
+	v := float64(vel) / 127
+	return 1 - r.ampVelTrack/100*(1-v*v)
+}
+
+// sampler plays SFZ instruments. A note is played by every region whose key and velocity ranges contain it, subject
+// to round-robin (seq_length and seq_position) and random (lorand and hirand) selection. Each region is repitched
+// relative to its key center and shaped by its own amplitude envelope. Held notes follow the pitch input, so pitch
+// bends carry through to the samples.
+type sampler struct {
+	rand                   *rand.Rand
+	pitch, gate, velocity  *In
+	a, b                   *Out
+	regions                []samplerRegion
+	voices                 []samplerVoice
+	sampleRate             float64
+	lastGate               float64
+	lastKey, lastVel, next int
+}
+
+func (s *sampler) ProcessSample(i int) {
+	var (
+		gate = s.gate.Read(i)
+		note = s.note(i)
+	)
+	switch {
+	case isTrig(s.lastGate, gate):
+		s.noteOn(note, s.velocity.Read(i))
+	case s.lastGate > 0 && gate <= 0:
+		s.noteOff()
+	}
+	s.lastGate = gate
+
+	var left, right float64
+	for j := range s.voices {
+		v := &s.voices[j]
+		if !v.active {
+			continue
+		}
+		if v.held {
+			v.note = note
+		}
+		l, r := v.tick()
+		left += l
+		right += r
+	}
+	s.a.Write(i, left)
+	s.b.Write(i, right)
+}
+
+// note converts the pitch input to a (fractional) MIDI note number.
+func (s *sampler) note(i int) float64 {
+	hz := s.pitch.Read(i) * s.sampleRate
+	if hz <= 0 {
+		return 0
+	}
+	return 69 + 12*math.Log2(hz/440)
+}
+
+func (s *sampler) noteOn(note, velocity float64) {
+	s.lastKey = int(dsp.Clamp(math.Round(note), 0, 127))
+	s.lastVel = int(dsp.Clamp(math.Round(velocity*127), 1, 127))
+	s.trigger(note, false)
+}
+
+// noteOff releases the voices of the held note and plays any release regions.
+func (s *sampler) noteOff() {
+	for j := range s.voices {
+		if v := &s.voices[j]; v.active && v.held {
+			v.held = false
+			if v.region.loopMode != sfzOneShot {
+				v.env.release()
+			}
+		}
+	}
+	s.trigger(float64(s.lastKey), true)
+}
+
+// trigger starts a voice for every region that plays for the last note.
+func (s *sampler) trigger(note float64, release bool) {
+	random := s.rand.Float64()
+	for j := range s.regions {
+		r := &s.regions[j]
+		if r.release != release || !r.matches(s.lastKey, s.lastVel, random) {
+			continue
+		}
+		r.sequence++
+		if (r.sequence-1)%r.seqLength != r.seqPosition-1 {
+			continue
+		}
+		v := s.allocate()
+		*v = samplerVoice{
+			active: true,
+			held:   !release,
+			region: r,
+			note:   note,
+			pos:    float64(r.offset),
+			gain:   r.velocityGain(s.lastVel),
+			order:  s.next,
+			ampeg:  samplerEnvelope(r.ampeg, s.sampleRate),
+		}
+		v.env.trigger()
+		s.next++
+	}
+}
+
+// allocate returns a free voice or, if all of them are busy, steals the oldest one.
+func (s *sampler) allocate() *samplerVoice {
+	oldest := &s.voices[0]
+	for j := range s.voices {
+		v := &s.voices[j]
+		if !v.active {
+			return v
+		}
+		if v.order < oldest.order {
+			oldest = v
+		}
+	}
+	return oldest
+}
+
+type samplerVoice struct {
+	active, held bool
+	region       *samplerRegion
+	note, pos    float64
+	gain         float64
+	order        int
+	ampeg        dahdsr
+	env          dahdsrEnvelope
+}
+
+// tick returns the voice's current output and advances it. Voices of release regions have no note to be released by,
+// so they play their sample through once rather than looping.
+func (v *samplerVoice) tick() (float64, float64) {
+	var (
+		r    = v.region
+		ph   = playhead{data: r.data, channels: r.channels}
+		loop = !r.release && (r.loopMode == sfzLoopContinuous || (r.loopMode == sfzLoopSustain && v.held))
+	)
+	if !loop && v.pos > r.end {
+		v.active = false
+		return 0, 0
+	}
+
+	var (
+		env   = v.env.tick(v.ampeg) * v.gain
+		left  = ph.at(v.pos, 0)
+		right = left
+	)
+	if r.channels > 1 {
+		right = ph.at(v.pos, 1)
+	}
+	if v.env.done() {
+		v.active = false
+	}
+
+	cents := (v.note-float64(r.keyCenter))*r.keyTrack + r.tune + 100*float64(r.transpose)
+	v.pos += math.Pow(2, cents/1200) * r.rateMult
+	if loop && v.pos > r.loopEnd+1 {
+		v.pos -= r.loopEnd + 1 - r.loopStart
+	}
+	return left * env * r.leftGain, right * env * r.rightGain
+}
+
+// samplerEnvelope converts an SFZ amplitude envelope's durations from seconds to samples.
+func samplerEnvelope(e sfzEnvelope, sampleRate float64) dahdsr {
+	return dahdsr{
+		delay:   e.delay * sampleRate,
+		attack:  e.attack * sampleRate,
+		hold:    e.hold * sampleRate,
+		decay:   e.decay * sampleRate,
+		sustain: e.sustain,
+		release: e.release * sampleRate,
+	}
+}
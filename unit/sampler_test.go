@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeSamplerWAV writes a mono WAV file to a directory.
+func writeSamplerWAV(t *testing.T, dir, name string, samples []float64) {
+	require.NoError(t, os.Rename(writeTestWAV(t, sampleRate, 1, samples), filepath.Join(dir, name)))
+}
+
+func constantSamples(v float64, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = v
+	}
+	return samples
+}
+
+func noteFreq(note float64) float64 {
+	return 440 * math.Pow(2, (note-69)/12) / sampleRate
+}
+
+// playNote holds a note for n samples, releases it, and returns the first output.
+func playNote(u *Unit, note, velocity float64, n int) []float64 {
+	out := make([]float64, frameSize)
+	for i := 0; i < frameSize; i++ {
+		gate := -1.0
+		if i > 0 && i <= n {
+			gate = 1
+		}
+		u.In["pitch"].Write(i, noteFreq(note))
+		u.In["velocity"].Write(i, velocity)
+		u.In["gate"].Write(i, gate)
+	}
+	u.ProcessFrame(frameSize)
+	for i := range out {
+		out[i] = u.Out["a"].Out().Read(i)
+	}
+	return out
+}
+
+func TestSampler_KeyAndVelocity(t *testing.T) {
+	dir := t.TempDir()
+	writeSamplerWAV(t, dir, "low.wav", constantSamples(0.25, 1000))
+	writeSamplerWAV(t, dir, "high.wav", constantSamples(0.5, 1000))
+	writeSamplerWAV(t, dir, "loud.wav", constantSamples(0.125, 1000))
+	file := writeTestSFZ(t, dir, `
+<group> amp_veltrack=0
+<region> sample=low.wav hikey=59
+<region> sample=high.wav lokey=60
+<region> sample=loud.wav lokey=60 lovel=100
+`)
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	out := playNote(u, 48, 1, 100)
+	require.Equal(t, 0.0, out[0])
+	require.InDelta(t, 0.25, out[10], 1e-4)
+
+	// Regions that share a note are layered.
+	out = playNote(u, 72, 1, 100)
+	require.InDelta(t, 0.625, out[10], 1e-4)
+	out = playNote(u, 72, 0.5, 100)
+	require.InDelta(t, 0.5, out[10], 1e-4)
+}
+
+func TestSampler_Repitch(t *testing.T) {
+	dir := t.TempDir()
+	ramp := make([]float64, 1000)
+	for i := range ramp {
+		ramp[i] = float64(i) / 1000
+	}
+	writeSamplerWAV(t, dir, "ramp.wav", ramp)
+	file := writeTestSFZ(t, dir, "<region> sample=ramp.wav pitch_keycenter=60 amp_veltrack=0")
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	out := playNote(u, 72, 1, 200)
+	for k := 1; k < 100; k++ {
+		require.InDelta(t, float64(2*(k-1))/1000, out[k], 1e-4)
+	}
+
+	file = writeTestSFZ(t, dir, "<region> sample=ramp.wav pitch_keycenter=60 pitch_keytrack=0 tune=1200 amp_veltrack=0")
+	u = newTestUnit(t, "sampler", map[string]any{"file": file})
+	out = playNote(u, 48, 1, 200)
+	require.InDelta(t, 0.02, out[11], 1e-4)
+}
+
+func TestSampler_RoundRobin(t *testing.T) {
+	dir := t.TempDir()
+	writeSamplerWAV(t, dir, "a.wav", constantSamples(0.25, 1000))
+	writeSamplerWAV(t, dir, "b.wav", constantSamples(0.5, 1000))
+	file := writeTestSFZ(t, dir, `
+<group> seq_length=2 amp_veltrack=0
+<region> sample=a.wav seq_position=1
+<region> sample=b.wav seq_position=2
+`)
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	// Releases are instant, so each note is heard alone.
+	for _, v := range []float64{0.25, 0.5, 0.25} {
+		out := playNote(u, 60, 1, 100)
+		require.InDelta(t, v, out[10], 1e-4)
+		require.Equal(t, 0.0, out[200])
+	}
+}
+
+func TestSampler_Envelope(t *testing.T) {
+	dir := t.TempDir()
+	writeSamplerWAV(t, dir, "a.wav", constantSamples(0.5, 2000))
+	file := writeTestSFZ(t, dir, fmt.Sprintf(
+		"<region> sample=a.wav amp_veltrack=0 ampeg_attack=%g ampeg_sustain=50 ampeg_release=%g",
+		10.0/sampleRate, 50.0/sampleRate,
+	))
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	out := playNote(u, 60, 1, 100)
+	require.InDelta(t, 0.05, out[1], 1e-4)  // attack
+	require.InDelta(t, 0.5, out[10], 1e-4)  // peak
+	require.InDelta(t, 0.25, out[90], 1e-3) // sustain
+	require.True(t, out[120] < 0.25 && out[120] > 0)
+	require.Equal(t, 0.0, out[200]) // released
+}
+
+func TestSampler_Loop(t *testing.T) {
+	dir := t.TempDir()
+	ramp := make([]float64, 100)
+	for i := range ramp {
+		ramp[i] = float64(i) / 100
+	}
+	writeSamplerWAV(t, dir, "ramp.wav", ramp)
+	file := writeTestSFZ(t, dir, "<region> sample=ramp.wav amp_veltrack=0 loop_mode=loop_continuous loop_start=50 loop_end=59")
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	out := playNote(u, 60, 1, 200)
+	require.InDelta(t, 0.55, out[56], 1e-4)
+	require.InDelta(t, 0.55, out[66], 1e-4)
+	require.InDelta(t, 0.55, out[156], 1e-4)
+	require.Equal(t, 0.0, out[230]) // released
+}
+
+func TestSampler_ReleaseLoop(t *testing.T) {
+	dir := t.TempDir()
+	writeSamplerWAV(t, dir, "release.wav", constantSamples(0.5, 100))
+	file := writeTestSFZ(t, dir, "<region> sample=release.wav amp_veltrack=0 trigger=release loop_mode=loop_continuous loop_start=50 loop_end=59")
+	u := newTestUnit(t, "sampler", map[string]any{"file": file})
+
+	// The release region plays once when the note is released, 20 samples in.
+	out := playNote(u, 60, 1, 20)
+	require.Equal(t, 0.0, out[10])
+	require.InDelta(t, 0.5, out[70], 1e-4)
+	require.Equal(t, 0.0, out[200])
+	for _, v := range u.SampleProcessor.(*sampler).voices {
+		require.False(t, v.active)
+	}
+}
+
+func TestSampler_InvalidConfig(t *testing.T) {
+	_, err := Builders()["sampler"](Config{})
+	require.Error(t, err)
+	require.Equal(t, "unit/sampler: no file specified", err.Error())
+
+	dir := t.TempDir()
+	_, err = Builders()["sampler"](Config{Values: map[string]any{"file": writeTestSFZ(t, dir, "<group> volume=1")}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "contains no regions")
+
+	_, err = Builders()["sampler"](Config{Values: map[string]any{"file": writeTestSFZ(t, dir, "<region> sample=missing.wav")}})
+	require.Error(t, err)
+}
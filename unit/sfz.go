@@ -0,0 +1,343 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brettbuddin/shaden/errors"
+)
+
+type sfzLoopMode int
+
+const (
+	sfzNoLoop sfzLoopMode = iota
+	sfzOneShot
+	sfzLoopContinuous
+	sfzLoopSustain
+)
+
+var sfzLoopModes = map[string]sfzLoopMode{
+	"no_loop":         sfzNoLoop,
+	"one_shot":        sfzOneShot,
+	"loop_continuous": sfzLoopContinuous,
+	"loop_sustain":    sfzLoopSustain,
+}
+
+// sfzEnvelope is a DAHDSR amplitude envelope. Durations are in seconds and the sustain level is in the range [0, 1].
+type sfzEnvelope struct {
+	delay, attack, hold, decay, sustain, release float64
+}
+
+// sfzRegion is a sample and the notes and velocities it plays for, along with how it's played back.
+type sfzRegion struct {
+	sample                 string
+	loKey, hiKey           int
+	loVel, hiVel           int
+	loRand, hiRand         float64
+	keyCenter              int
+	keyTrack, tune         float64 // cents
+	transpose              int
+	volume, pan            float64 // dB; [-100, 100]
+	ampVelTrack            float64 // percent
+	offset, end            int     // frames; end is -1 if unset
+	loopMode               sfzLoopMode
+	loopStart, loopEnd     int // frames; -1 if unset
+	release                bool
+	seqLength, seqPosition int
+	ampeg                  sfzEnvelope
+}
+
+// matches reports whether the region plays for a note, velocity and random number in the range [0, 1).
+func (r *sfzRegion) matches(key, vel int, random float64) bool {
+	return key >= r.loKey && key <= r.hiKey &&
+		vel >= r.loVel && vel <= r.hiVel &&
+		random >= r.loRand && random < r.hiRand
+}
+
+var (
+	sfzComment  = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	sfzToken    = regexp.MustCompile(`<\w+>|\b\w+=`)
+	sfzNoteName = regexp.MustCompile(`^([a-gA-G])(#|b)?(-?\d+)$`)
+	sfzNotes    = map[byte]int{'c': 0, 'd': 2, 'e': 4, 'f': 5, 'g': 7, 'a': 9, 'b': 11}
+)
+
+// sfzOpcodes are the opcodes in effect at a header, along with the lines they were set on.
+type sfzOpcodes map[string]sfzOpcode
+
+type sfzOpcode struct {
+	value string
+	line  int
+}
+
+// parseSFZ reads the regions of an SFZ file. The paths of their samples are resolved relative to the file. Opcodes
+// that aren't supported are ignored, as is common among SFZ players.
+func parseSFZ(path string) ([]*sfzRegion, error) {
+	text, err := readSFZ(path, map[string]string{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dir                            = filepath.Dir(path)
+		control, global, master, group = sfzOpcodes{}, sfzOpcodes{}, sfzOpcodes{}, sfzOpcodes{}
+		region                         sfzOpcodes
+		current                        = sfzOpcodes{} // opcodes outside of any header are ignored
+		regions                        []*sfzRegion
+	)
+	flush := func() error {
+		if region == nil {
+			return nil
+		}
+		merged := sfzOpcodes{}
+		for _, scope := range []sfzOpcodes{global, master, group, region} {
+			for k, v := range scope {
+				merged[k] = v
+			}
+		}
+		r, err := newSFZRegion(merged, filepath.Join(dir, sfzPath(control["default_path"].value)))
+		if err != nil {
+			return errors.Wrapf(err, "%s", path)
+		}
+		regions = append(regions, r)
+		region = nil
+		return nil
+	}
+
+	for n, line := range strings.Split(text, "\n") {
+		tokens := sfzToken.FindAllStringIndex(line, -1)
+		for j, loc := range tokens {
+			token := line[loc[0]:loc[1]]
+			if strings.HasPrefix(token, "<") {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				switch token {
+				case "<control>":
+					current = control
+				case "<global>":
+					global, master, group = sfzOpcodes{}, sfzOpcodes{}, sfzOpcodes{}
+					current = global
+				case "<master>":
+					master, group = sfzOpcodes{}, sfzOpcodes{}
+					current = master
+				case "<group>":
+					group = sfzOpcodes{}
+					current = group
+				case "<region>":
+					region = sfzOpcodes{}
+					current = region
+				default:
+					current = sfzOpcodes{} // e.g. <curve> and <effect>, which aren't supported
+				}
+				continue
+			}
+
+			end := len(line)
+			if j+1 < len(tokens) {
+				end = tokens[j+1][0]
+			}
+			current[strings.TrimSuffix(token, "=")] = sfzOpcode{
+				value: strings.TrimSpace(line[loc[1]:end]),
+				line:  n + 1,
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+// readSFZ reads an SFZ file, strips its comments and expands its #define and #include directives.
+func readSFZ(path string, defines map[string]string, depth int) (string, error) {
+	if depth > 16 {
+		return "", errors.Errorf("%s: #include nested too deeply", path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Comments are replaced with newlines they contain so that line numbers still line up.
+	text := sfzComment.ReplaceAllStringFunc(string(raw), func(s string) string {
+		return strings.Repeat("\n", strings.Count(s, "\n"))
+	})
+
+	lines := strings.Split(text, "\n")
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#define"):
+			fields := strings.Fields(trimmed)
+			if len(fields) < 3 {
+				return "", errors.Errorf("%s:%d: invalid #define", path, n+1)
+			}
+			defines[fields[1]] = strings.Join(fields[2:], " ")
+			lines[n] = ""
+		case strings.HasPrefix(trimmed, "#include"):
+			name := strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "#include")), `"`)
+			included, err := readSFZ(filepath.Join(filepath.Dir(path), sfzPath(name)), defines, depth+1)
+			if err != nil {
+				return "", errors.Wrapf(err, "%s:%d", path, n+1)
+			}
+			// Included text is joined onto one line so that the line numbers of the including file are preserved.
+			lines[n] = strings.Replace(included, "\n", " ", -1)
+		default:
+			lines[n] = expandDefines(line, defines)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// expandDefines replaces each $name in a line that has been defined. Names run as far as the letters, digits and
+// underscores that follow the $, so a name is never mistaken for a longer one that it prefixes.
+func expandDefines(line string, defines map[string]string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(line, '$')
+		if i < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+		end := i + 1
+		for end < len(line) && isSFZNameByte(line[end]) {
+			end++
+		}
+		b.WriteString(line[:i])
+		if value, ok := defines[line[i:end]]; ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(line[i:end])
+		}
+		line = line[end:]
+	}
+}
+
+func isSFZNameByte(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func newSFZRegion(ops sfzOpcodes, dir string) (*sfzRegion, error) {
+	r := &sfzRegion{
+		hiKey:       127,
+		loVel:       1,
+		hiVel:       127,
+		hiRand:      1,
+		keyCenter:   60,
+		keyTrack:    100,
+		ampVelTrack: 100,
+		end:         -1,
+		loopStart:   -1,
+		loopEnd:     -1,
+		seqLength:   1,
+		seqPosition: 1,
+		ampeg:       sfzEnvelope{sustain: 1},
+	}
+
+	sample, ok := ops["sample"]
+	if !ok {
+		return nil, errors.New("region has no sample")
+	}
+	r.sample = filepath.Join(dir, sfzPath(sample.value))
+
+	var err error
+	setInt := func(dst *int, name string, parse func(string) (int, error)) {
+		if op, ok := ops[name]; ok && err == nil {
+			if *dst, err = parse(op.value); err != nil {
+				err = errors.Errorf("line %d: invalid value %q for %s", op.line, op.value, name)
+			}
+		}
+	}
+	setFloat := func(dst *float64, name string, scale float64) {
+		if op, ok := ops[name]; ok && err == nil {
+			var v float64
+			if v, err = strconv.ParseFloat(op.value, 64); err != nil {
+				err = errors.Errorf("line %d: invalid value %q for %s", op.line, op.value, name)
+			}
+			*dst = v * scale
+		}
+	}
+
+	setInt(&r.loKey, "key", parseSFZNote)
+	setInt(&r.hiKey, "key", parseSFZNote)
+	setInt(&r.keyCenter, "key", parseSFZNote)
+	setInt(&r.loKey, "lokey", parseSFZNote)
+	setInt(&r.hiKey, "hikey", parseSFZNote)
+	setInt(&r.keyCenter, "pitch_keycenter", parseSFZNote)
+	setInt(&r.loVel, "lovel", strconv.Atoi)
+	setInt(&r.hiVel, "hivel", strconv.Atoi)
+	setInt(&r.transpose, "transpose", strconv.Atoi)
+	setInt(&r.offset, "offset", strconv.Atoi)
+	setInt(&r.end, "end", strconv.Atoi)
+	setInt(&r.loopStart, "loop_start", strconv.Atoi)
+	setInt(&r.loopEnd, "loop_end", strconv.Atoi)
+	setInt(&r.loopStart, "loopstart", strconv.Atoi)
+	setInt(&r.loopEnd, "loopend", strconv.Atoi)
+	setInt(&r.seqLength, "seq_length", strconv.Atoi)
+	setInt(&r.seqPosition, "seq_position", strconv.Atoi)
+	setFloat(&r.loRand, "lorand", 1)
+	setFloat(&r.hiRand, "hirand", 1)
+	setFloat(&r.keyTrack, "pitch_keytrack", 1)
+	setFloat(&r.tune, "tune", 1)
+	setFloat(&r.volume, "volume", 1)
+	setFloat(&r.pan, "pan", 1)
+	setFloat(&r.ampVelTrack, "amp_veltrack", 1)
+	setFloat(&r.ampeg.delay, "ampeg_delay", 1)
+	setFloat(&r.ampeg.attack, "ampeg_attack", 1)
+	setFloat(&r.ampeg.hold, "ampeg_hold", 1)
+	setFloat(&r.ampeg.decay, "ampeg_decay", 1)
+	setFloat(&r.ampeg.sustain, "ampeg_sustain", 0.01)
+	setFloat(&r.ampeg.release, "ampeg_release", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"loop_mode", "loopmode"} {
+		if op, ok := ops[name]; ok {
+			if r.loopMode, ok = sfzLoopModes[op.value]; !ok {
+				return nil, errors.Errorf("line %d: invalid value %q for %s", op.line, op.value, name)
+			}
+		}
+	}
+	if op, ok := ops["trigger"]; ok {
+		switch op.value {
+		case "attack", "first", "legato":
+		case "release":
+			r.release = true
+		default:
+			return nil, errors.Errorf("line %d: invalid value %q for trigger", op.line, op.value)
+		}
+	}
+	if r.seqLength < 1 {
+		r.seqLength = 1
+	}
+	return r, nil
+}
+
+// sfzPath converts a path within an SFZ file, which may use either kind of slash, to one for the local system.
+func sfzPath(p string) string {
+	return filepath.FromSlash(strings.Replace(p, `\`, "/", -1))
+}
+
+// parseSFZNote parses a MIDI note number or a note name such as c4, c#4 or db4, where c4 is note 60.
+func parseSFZNote(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	m := sfzNoteName.FindStringSubmatch(s)
+	if m == nil {
+		return 0, errors.Errorf("invalid note %q", s)
+	}
+	octave, _ := strconv.Atoi(m[3])
+	note := sfzNotes[strings.ToLower(m[1])[0]] + (octave+1)*12
+	switch m[2] {
+	case "#":
+		note++
+	case "b":
+		note--
+	}
+	return note, nil
+}
@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestSFZ(t *testing.T, dir, text string) string {
+	path := filepath.Join(dir, "test.sfz")
+	require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+	return path
+}
+
+func TestParseSFZ(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common.sfzh"), []byte("ampeg_release=0.5"), 0644))
+	path := writeTestSFZ(t, dir, `
+// A comment
+#define $VOL -6
+<control> default_path=samples\
+<global> volume=$VOL
+#include "common.sfzh"
+<group> seq_length=2 lovel=64 /* a comment
+spanning lines */
+<region> sample=piano c4.wav key=c4 seq_position=1
+<region> sample=piano c4 rr.wav key=60 seq_position=2 ampeg_sustain=50
+<group>
+<region> sample=piano d4.wav lokey=c#4 hikey=eb4 pitch_keycenter=d4 loop_mode=loop_sustain trigger=release
+`)
+
+	regions, err := parseSFZ(path)
+	require.NoError(t, err)
+	require.Len(t, regions, 3)
+
+	r := regions[0]
+	require.Equal(t, filepath.Join(dir, "samples", "piano c4.wav"), r.sample)
+	require.Equal(t, 60, r.loKey)
+	require.Equal(t, 60, r.hiKey)
+	require.Equal(t, 60, r.keyCenter)
+	require.Equal(t, 64, r.loVel)
+	require.Equal(t, 127, r.hiVel)
+	require.Equal(t, -6.0, r.volume)
+	require.Equal(t, 2, r.seqLength)
+	require.Equal(t, 1, r.seqPosition)
+	require.Equal(t, 0.5, r.ampeg.release)
+	require.Equal(t, 1.0, r.ampeg.sustain)
+
+	r = regions[1]
+	require.Equal(t, filepath.Join(dir, "samples", "piano c4 rr.wav"), r.sample)
+	require.Equal(t, 2, r.seqPosition)
+	require.Equal(t, 0.5, r.ampeg.sustain)
+
+	// The second group resets the opcodes of the first.
+	r = regions[2]
+	require.Equal(t, 61, r.loKey)
+	require.Equal(t, 63, r.hiKey)
+	require.Equal(t, 62, r.keyCenter)
+	require.Equal(t, 1, r.loVel)
+	require.Equal(t, 1, r.seqLength)
+	require.Equal(t, sfzLoopSustain, r.loopMode)
+	require.True(t, r.release)
+	require.Equal(t, -6.0, r.volume)
+}
+
+func TestExpandDefines(t *testing.T) {
+	defines := map[string]string{"$VEL": "64", "$VEL2": "100", "$KEY": "c4"}
+	for i := 0; i < 20; i++ {
+		require.Equal(t, "lovel=64 hivel=100 key=c4 pan=$PAN cost=$", expandDefines("lovel=$VEL hivel=$VEL2 key=$KEY pan=$PAN cost=$", defines))
+	}
+}
+
+func TestParseSFZ_Errors(t *testing.T) {
+	dir := t.TempDir()
+	for _, test := range []struct {
+		text, err string
+	}{
+		{"<region> key=60", "region has no sample"},
+		{"<region> sample=a.wav\n lokey=h2", `line 2: invalid value "h2" for lokey`},
+		{"<region> sample=a.wav loop_mode=forever", `line 1: invalid value "forever" for loop_mode`},
+		{"<region> sample=a.wav trigger=never", `line 1: invalid value "never" for trigger`},
+	} {
+		_, err := parseSFZ(writeTestSFZ(t, dir, test.text))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), test.err)
+	}
+}
+
+func TestParseSFZNote(t *testing.T) {
+	for s, n := range map[string]int{"60": 60, "c4": 60, "C4": 60, "c#4": 61, "db4": 61, "a4": 69, "c-1": 0, "g9": 127} {
+		v, err := parseSFZNote(s)
+		require.NoError(t, err)
+		require.Equal(t, n, v, s)
+	}
+	_, err := parseSFZNote("x4")
+	require.Error(t, err)
+}
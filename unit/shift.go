@@ -6,9 +6,9 @@ import (
 
 func newShift(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &shift{
-		in:        io.NewIn("in", dsp.Float64(0)),
-		semitones: io.NewIn("semitones", dsp.Float64(0)),
-		out:       io.NewOut("out"),
+		in:        io.NewIn("in", dsp.Float64(0), WithDoc("signal to shift")),
+		semitones: io.NewIn("semitones", dsp.Float64(0), WithMeasure(MeasureSemitones), WithDoc("amount to shift the pitch")),
+		out:       io.NewOut("out", WithDoc("pitch shifted signal")),
 		shift:     dsp.NewPitchShift(),
 	}), nil
 }
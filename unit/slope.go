@@ -12,17 +12,17 @@ func newSlope(io *IO, c Config) (*Unit, error) {
 			lastTrigger: -1,
 		},
 		stateFunc: slopeIdle,
-		trigger:   io.NewIn("trigger", dsp.Float64(-1)),
-		gate:      io.NewIn("gate", dsp.Float64(-1)),
-		rise:      io.NewIn("rise", dsp.Duration(100, c.SampleRate)),
-		fall:      io.NewIn("fall", dsp.Duration(100, c.SampleRate)),
-		retrigger: io.NewIn("retrigger", dsp.Float64(1)),
-		cycle:     io.NewIn("cycle", dsp.Float64(0)),
-		ratio:     io.NewIn("ratio", dsp.Float64(0.01)),
-		out:       io.NewOut("out"),
-		mirror:    io.NewOut("mirror"),
-		eoc:       io.NewOut("eoc"),
-		eor:       io.NewOut("eor"),
+		trigger:   io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("starts the rise")),
+		gate:      io.NewIn("gate", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("starts the rise and holds at the peak while high")),
+		rise:      io.NewIn("rise", dsp.Duration(100, c.SampleRate), WithDoc("duration of the rise")),
+		fall:      io.NewIn("fall", dsp.Duration(100, c.SampleRate), WithDoc("duration of the fall")),
+		retrigger: io.NewIn("retrigger", dsp.Float64(1), WithMeasure(MeasureGate), WithDoc("allows triggers to restart the slope while falling")),
+		cycle:     io.NewIn("cycle", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("loops the slope while high")),
+		ratio:     io.NewIn("ratio", dsp.Float64(0.01), WithDoc("curvature of the segments")),
+		out:       io.NewOut("out", WithRange(0, 1), WithDoc("envelope")),
+		mirror:    io.NewOut("mirror", WithRange(0, 1), WithDoc("inverted envelope")),
+		eoc:       io.NewOut("eoc", WithMeasure(MeasureGate), WithDoc("high at the end of the cycle")),
+		eor:       io.NewOut("eor", WithMeasure(MeasureGate), WithDoc("high at the end of the rise")),
 	}), nil
 }
 
@@ -35,12 +35,30 @@ type slope struct {
 
 func (s *slope) ProcessSample(i int) {
 	s.state.trigger = s.trigger.Read(i)
-	s.state.retrigger = s.retrigger.ReadSlow(i, ident)
 	s.state.gate = s.gate.Read(i)
+	s.read(i)
+	s.tick(i)
+}
+
+// ProcessControl implements ControlProcessor. Durations are converted from samples to control periods.
+func (s *slope) ProcessControl(n int) {
+	s.state.trigger = s.trigger.readPeak(n)
+	s.state.gate = s.gate.readPeak(n)
+	s.read(0)
+	s.state.rise /= float64(n)
+	s.state.fall /= float64(n)
+	s.tick(0)
+}
+
+func (s *slope) read(i int) {
+	s.state.retrigger = s.retrigger.ReadSlow(i, ident)
 	s.state.rise = math.Abs(s.rise.Read(i))
 	s.state.fall = math.Abs(s.fall.Read(i))
 	s.state.cycle = s.cycle.Read(i)
 	s.state.ratio = s.ratio.Read(i)
+}
+
+func (s *slope) tick(i int) {
 	s.stateFunc = s.stateFunc(s.state)
 	s.state.lastTrigger = s.state.trigger
 	s.state.lastGate = s.state.gate
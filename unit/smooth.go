@@ -6,9 +6,9 @@ import (
 
 func newSmooth(io *IO, c Config) (*Unit, error) {
 	return NewUnit(io, &smooth{
-		in:      io.NewIn("in", dsp.Float64(0)),
-		time:    io.NewIn("time", dsp.Duration(100, c.SampleRate)),
-		out:     io.NewOut("out"),
+		in:      io.NewIn("in", dsp.Float64(0), WithDoc("signal to smooth")),
+		time:    io.NewIn("time", dsp.Duration(100, c.SampleRate), WithDoc("window of the rolling average")),
+		out:     io.NewOut("out", WithDoc("smoothed signal")),
 		average: dsp.RollingAverage{},
 	}), nil
 }
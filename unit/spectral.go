@@ -0,0 +1,182 @@
+package unit
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultSpectralSize = 2048
+	minSpectralSize     = 64
+	maxSpectralSize     = 32768
+	spectralOverlap     = 4
+)
+
+// newSpectralSTFT decodes the frame size of a spectral unit and returns an STFT that runs process on each frame.
+func newSpectralSTFT(c Config, process func([]complex128)) (*dsp.STFT, error) {
+	var config struct {
+		Size int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Size == 0 {
+		config.Size = defaultSpectralSize
+	} else if !dsp.IsPowerOfTwo(config.Size) || config.Size < minSpectralSize || config.Size > maxSpectralSize {
+		return nil, c.errorf("size must be a power of two between %d and %d, got %d", minSpectralSize, maxSpectralSize, config.Size)
+	}
+	return dsp.NewSTFT(config.Size, spectralOverlap, dsp.Hann, process), nil
+}
+
+// spectralAmplitude returns the scale that converts the magnitude of a bin to the amplitude of a sine centered on it.
+func spectralAmplitude(stft *dsp.STFT) float64 {
+	// A Hann window averages to 1/2 and a sine's energy is split between positive and negative frequencies.
+	return 4 / float64(stft.Size())
+}
+
+func newSpectralFreeze(io *IO, c Config) (*Unit, error) {
+	f := &spectralFreeze{
+		in:     io.NewIn("in", dsp.Float64(0), WithDoc("signal to freeze")),
+		freeze: io.NewIn("freeze", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("holds the spectrum while high")),
+		out:    io.NewOut("out", WithDoc("signal, or its frozen spectrum")),
+	}
+	stft, err := newSpectralSTFT(c, f.process)
+	if err != nil {
+		return nil, err
+	}
+	bins := stft.Size()/2 + 1
+	f.stft = stft
+	f.magnitudes = make([]float64, bins)
+	f.phases = make([]float64, bins)
+	f.advances = make([]float64, bins)
+	f.last = make([]float64, bins)
+	return NewUnit(io, f), nil
+}
+
+// spectralFreeze holds the spectrum of its input while frozen. Each bin keeps the magnitude it had when it was frozen
+// and its phase keeps turning at the rate it was turning then, so partials carry on at their own frequencies.
+type spectralFreeze struct {
+	in, freeze *In
+	out        *Out
+	stft       *dsp.STFT
+
+	frozen, wasFrozen bool
+	magnitudes        []float64
+	phases, advances  []float64
+	last              []float64 // phases of the previous frame
+}
+
+func (f *spectralFreeze) ProcessSample(i int) {
+	f.frozen = f.freeze.Read(i) > 0
+	f.out.Write(i, f.stft.Tick(f.in.Read(i)))
+}
+
+func (f *spectralFreeze) process(spectrum []complex128) {
+	if !f.frozen {
+		for k, v := range spectrum {
+			f.last[k] = cmplx.Phase(v)
+		}
+		f.wasFrozen = false
+		return
+	}
+
+	if !f.wasFrozen {
+		for k, v := range spectrum {
+			f.magnitudes[k], f.phases[k] = cmplx.Abs(v), cmplx.Phase(v)
+			f.advances[k] = f.phases[k] - f.last[k]
+		}
+		f.wasFrozen = true
+	} else {
+		for k := range spectrum {
+			f.phases[k] = math.Mod(f.phases[k]+f.advances[k], twoPi)
+		}
+	}
+	for k := range spectrum {
+		spectrum[k] = cmplx.Rect(f.magnitudes[k], f.phases[k])
+	}
+}
+
+func newSpectralGate(io *IO, c Config) (*Unit, error) {
+	g := &spectralGate{
+		in:        io.NewIn("in", dsp.Float64(0), WithDoc("signal to gate")),
+		threshold: io.NewIn("threshold", dsp.Float64(0.01), WithRange(0, 1), WithDoc("amplitude below which a frequency is reduced")),
+		floor:     io.NewIn("floor", dsp.Float64(0), WithRange(0, 1), WithDoc("gain of frequencies below the threshold")),
+		out:       io.NewOut("out", WithDoc("gated signal")),
+	}
+	stft, err := newSpectralSTFT(c, g.process)
+	if err != nil {
+		return nil, err
+	}
+	g.stft = stft
+	g.scale = spectralAmplitude(stft)
+	return NewUnit(io, g), nil
+}
+
+// spectralGate reduces each frequency of its input that's quieter than a threshold. With a threshold just above the
+// level of a noise floor, it removes the noise from between the louder parts of a signal.
+type spectralGate struct {
+	in, threshold, floor *In
+	out                  *Out
+	stft                 *dsp.STFT
+	scale                float64
+
+	thresholdValue, floorValue float64
+}
+
+func (g *spectralGate) ProcessSample(i int) {
+	g.thresholdValue = g.threshold.Read(i)
+	g.floorValue = dsp.Clamp(g.floor.Read(i), 0, 1)
+	g.out.Write(i, g.stft.Tick(g.in.Read(i)))
+}
+
+func (g *spectralGate) process(spectrum []complex128) {
+	floor := complex(g.floorValue, 0)
+	for k, v := range spectrum {
+		if cmplx.Abs(v)*g.scale < g.thresholdValue {
+			spectrum[k] = v * floor
+		}
+	}
+}
+
+func newSpectralBlur(io *IO, c Config) (*Unit, error) {
+	b := &spectralBlur{
+		in:     io.NewIn("in", dsp.Float64(0), WithDoc("signal to blur")),
+		amount: io.NewIn("amount", dsp.Float64(0.5), WithRange(0, 1), WithDoc("how long each frequency is smeared over")),
+		out:    io.NewOut("out", WithDoc("blurred signal")),
+	}
+	stft, err := newSpectralSTFT(c, b.process)
+	if err != nil {
+		return nil, err
+	}
+	b.stft = stft
+	b.magnitudes = make([]float64, stft.Size()/2+1)
+	return NewUnit(io, b), nil
+}
+
+// spectralBlur smears its input over time. The magnitude of each frequency follows the input's slowly, while its phase
+// follows the input's directly, so sounds swell and linger as a wash.
+type spectralBlur struct {
+	in, amount  *In
+	out         *Out
+	stft        *dsp.STFT
+	magnitudes  []float64
+	amountValue float64
+}
+
+func (b *spectralBlur) ProcessSample(i int) {
+	b.amountValue = dsp.Clamp(b.amount.Read(i), 0, 1)
+	b.out.Write(i, b.stft.Tick(b.in.Read(i)))
+}
+
+func (b *spectralBlur) process(spectrum []complex128) {
+	// Each frame moves the magnitudes toward the input's by a share that falls away steeply as the amount rises, so the
+	// upper end of the range smears over seconds.
+	share := math.Max(math.Pow(1-b.amountValue, 2), 0.001)
+	for k, v := range spectrum {
+		b.magnitudes[k] += (cmplx.Abs(v) - b.magnitudes[k]) * share
+		spectrum[k] = cmplx.Rect(b.magnitudes[k], cmplx.Phase(v))
+	}
+}
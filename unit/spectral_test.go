@@ -0,0 +1,87 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runSpectral processes frames of input, set per sample by inputs, and returns the peak of the output over each frame.
+func runSpectral(u *Unit, frames int, inputs func(n int) map[string]float64) []float64 {
+	peaks := make([]float64, frames)
+	for f := range peaks {
+		for i := 0; i < frameSize; i++ {
+			for name, v := range inputs(f*frameSize + i) {
+				u.In[name].Write(i, v)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			peaks[f] = math.Max(peaks[f], math.Abs(u.Out["out"].Out().Read(i)))
+		}
+	}
+	return peaks
+}
+
+func spectralSine(amp float64, n int) float64 {
+	return amp * math.Sin(2*math.Pi*440*float64(n)/sampleRate)
+}
+
+func TestSpectralFreeze(t *testing.T) {
+	u := newTestUnit(t, "spectral-freeze", map[string]any{"size": 1024})
+
+	// A sine plays for 40 frames, the spectrum is frozen at frame 30 and the sine stops at frame 40.
+	peaks := runSpectral(u, 100, func(n int) map[string]float64 {
+		var (
+			frame  = n / frameSize
+			in     float64
+			freeze = -1.0
+		)
+		if frame < 40 {
+			in = spectralSine(0.5, n)
+		}
+		if frame >= 30 && frame < 80 {
+			freeze = 1
+		}
+		return map[string]float64{"in": in, "freeze": freeze}
+	})
+	require.InDelta(t, 0.5, peaks[20], 0.01)
+	for f := 50; f < 80; f++ {
+		require.InDelta(t, 0.5, peaks[f], 0.05, "frame %d", f)
+	}
+	require.InDelta(t, 0, peaks[95], 1e-6)
+}
+
+func TestSpectralGate(t *testing.T) {
+	gate := func(amp float64) float64 {
+		u := newTestUnit(t, "spectral-gate", map[string]any{"size": 1024})
+		peaks := runSpectral(u, 40, func(n int) map[string]float64 {
+			return map[string]float64{"in": spectralSine(amp, n), "threshold": 0.05}
+		})
+		return peaks[30]
+	}
+	require.InDelta(t, 0.5, gate(0.5), 0.01)
+	require.InDelta(t, 0, gate(0.01), 1e-3)
+}
+
+func TestSpectralBlur(t *testing.T) {
+	tail := func(amount float64) float64 {
+		u := newTestUnit(t, "spectral-blur", map[string]any{"size": 1024})
+		peaks := runSpectral(u, 60, func(n int) map[string]float64 {
+			var in float64
+			if n < 20*frameSize {
+				in = spectralSine(0.5, n)
+			}
+			return map[string]float64{"in": in, "amount": amount}
+		})
+		return peaks[40]
+	}
+	require.InDelta(t, 0, tail(0), 1e-6)
+	require.True(t, tail(0.9) > 0.01)
+}
+
+func TestSpectral_InvalidSize(t *testing.T) {
+	_, err := Builders()["spectral-blur"](Config{Values: map[string]any{"size": 1000}})
+	require.EqualError(t, err, "unit/spectral-blur: size must be a power of two between 64 and 32768, got 1000")
+}
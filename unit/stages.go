@@ -38,26 +38,26 @@ func newStages(io *IO, c Config) (*Unit, error) {
 	stageInputs := make([]*stage, config.Size)
 	for i := range stageInputs {
 		stageInputs[i] = &stage{
-			freq:   io.NewIn(fmt.Sprintf("%d/freq", i), dsp.Float64(0)),
-			pulses: io.NewIn(fmt.Sprintf("%d/pulses", i), dsp.Float64(1)),
-			mode:   io.NewIn(fmt.Sprintf("%d/mode", i), dsp.Float64(pulseModeFirst)),
-			glide:  io.NewIn(fmt.Sprintf("%d/glide", i), dsp.Float64(0)),
-			data:   io.NewIn(fmt.Sprintf("%d/data", i), dsp.Float64(0)),
+			freq:   io.NewIn(fmt.Sprintf("%d/freq", i), dsp.Float64(0), WithoutSmoothing(), WithDoc("frequency output by the stage")),
+			pulses: io.NewIn(fmt.Sprintf("%d/pulses", i), dsp.Float64(1), WithMeasure(MeasureInteger), WithDoc("number of clock pulses the stage lasts")),
+			mode:   io.NewIn(fmt.Sprintf("%d/mode", i), dsp.Float64(pulseModeFirst), WithMeasure(MeasureMode), WithRange(0, 4), WithDoc("gate mode: rest, first, last, all or hold")),
+			glide:  io.NewIn(fmt.Sprintf("%d/glide", i), dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("glides into the stage's frequency when high")),
+			data:   io.NewIn(fmt.Sprintf("%d/data", i), dsp.Float64(0), WithoutSmoothing(), WithDoc("value output by the stage on the data output")),
 		}
 	}
 
 	return NewUnit(io, &stages{
 		rand:        c.Rand,
-		clock:       io.NewIn("clock", dsp.Float64(-1)),
-		mode:        io.NewIn("mode", dsp.Float64(patternModeForward)),
-		reset:       io.NewIn("reset", dsp.Float64(-1)),
-		totalStages: io.NewIn("stages", dsp.Float64(config.Size)),
-		glideTime:   io.NewIn("glide-time", dsp.Float64(0)),
-		out:         io.NewOut("freq"),
-		gate:        io.NewOut("gate"),
-		data:        io.NewOut("data"),
-		eos:         io.NewOut("eos"),
-		sop:         io.NewOut("sop"),
+		clock:       io.NewIn("clock", dsp.Float64(-1), WithMeasure(MeasureGate), WithDoc("advances the sequence")),
+		mode:        io.NewIn("mode", dsp.Float64(patternModeForward), WithMeasure(MeasureMode), WithRange(0, 3), WithDoc("forward, reverse, pingpong or random")),
+		reset:       io.NewIn("reset", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("returns to the first stage")),
+		totalStages: io.NewIn("stages", dsp.Float64(config.Size), WithMeasure(MeasureInteger), WithDoc("number of stages in use")),
+		glideTime:   io.NewIn("glide-time", dsp.Float64(0), WithDoc("duration of glides")),
+		out:         io.NewOut("freq", WithMeasure(MeasureHz), WithDoc("frequency of the current stage")),
+		gate:        io.NewOut("gate", WithMeasure(MeasureGate), WithDoc("gate of the current stage")),
+		data:        io.NewOut("data", WithDoc("data of the current stage")),
+		eos:         io.NewOut("eos", WithMeasure(MeasureGate), WithDoc("high at the end of each stage")),
+		sop:         io.NewOut("sop", WithMeasure(MeasureGate), WithDoc("high at the start of the pattern")),
 		slew:        newSlew(),
 		stageInputs: stageInputs,
 		pulse:       -1,
@@ -0,0 +1,360 @@
+package unit
+
+import (
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/errors"
+)
+
+const (
+	streamRingFrames  = 1 << 15 // frames read ahead of playback (~0.75s at 44.1kHz)
+	streamBlockFrames = 4096    // frames decoded per read of the file
+	streamHeadLength  = 2       // seconds at the start of the file held in memory
+	streamWakeFrames  = 1024    // frames of ring space freed by playback before the reader is woken
+)
+
+func newSampleStream(io *IO, c Config, path string) (*Unit, error) {
+	if path == "" {
+		return nil, c.errorf("streaming requires a file")
+	}
+	file, err := openAudioFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	format := file.format()
+	if format.frames == 0 {
+		return nil, c.errorf("%q has no samples or an unknown length and can't be streamed", path)
+	}
+
+	head := make([]float64, int(math.Min(float64(format.frames), float64(streamHeadLength*format.sampleRate)))*format.channels)
+	if err := readFull(file, head); err != nil {
+		return nil, errors.Wrapf(err, "decoding %q", path)
+	}
+
+	s := &sampleStream{
+		trigger:     io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("starts playback")),
+		begin:       io.NewIn("begin", dsp.Float64(0), WithRange(0, 1), WithDoc("start of the playback region")),
+		end:         io.NewIn("end", dsp.Float64(1), WithRange(0, 1), WithDoc("end of the playback region")),
+		cycle:       io.NewIn("cycle", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("loops the playback region while high")),
+		rate:        io.NewIn("rate", dsp.Float64(1), WithDoc("playback speed where 1 is the original speed")),
+		pitch:       io.NewIn("pitch", dsp.Float64(0), WithMeasure(MeasureSemitones), WithDoc("transposition of playback")),
+		a:           io.NewOut("a", WithDoc("first channel")),
+		b:           io.NewOut("b", WithDoc("second channel")),
+		path:        path,
+		channels:    format.channels,
+		frames:      format.frames,
+		rateMult:    sampleRateRatio(format.sampleRate, c.SampleRate),
+		head:        playhead{data: head, channels: format.channels},
+		ring:        make([]float64, streamRingFrames*format.channels),
+		frame:       make([]float64, 2),
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		lastTrigger: -1,
+	}
+	return NewUnit(io, s), nil
+}
+
+// sampleStream plays a file back from disk rather than from memory. A goroutine decodes the file into a ring buffer
+// ahead of playback. The first few seconds of the file are held in memory, so playback triggered within them starts
+// immediately while the goroutine catches up; elsewhere, playback starts once the goroutine has read far enough.
+//
+// The goroutine, and the file it reads, are only opened once playback is first triggered. Units that are built but
+// never run hold neither, so they needn't be closed; Units that run are closed when they're removed from the graph.
+// Playback can't be reversed or crossfaded, so the direction and crossfade inputs of unit/sample aren't available.
+//
+// Playback only moves forward. Each trigger starts a new request that's numbered by reqGen and acknowledged by the
+// goroutine through ackGen. Positions within a request are counted in frames from its start, so the loops of a cycling
+// region are laid out one after the other in the ring.
+type sampleStream struct {
+	trigger, begin, end, cycle, rate, pitch *In
+	a, b                                    *Out
+
+	path             string
+	channels, frames int
+	rateMult         float64
+	head             playhead
+	frame            []float64
+	lastTrigger      float64
+
+	// Playback state, owned by the audio thread.
+	playing bool
+	pos     float64
+	from    int
+	gen     uint64
+	woken   int64 // read position when the goroutine was last woken
+
+	// State shared with the reading goroutine.
+	ring                    []float64
+	reqGen, ackGen          atomic.Uint64
+	reqFrom                 atomic.Int64
+	regionBegin, regionEnd  atomic.Int64
+	loop                    atomic.Bool
+	written, endAt, readPos atomic.Int64
+	wake, done              chan struct{}
+	wg                      sync.WaitGroup
+
+	mu              sync.Mutex // guards starting and closing the goroutine
+	started, closed bool
+}
+
+func (s *sampleStream) ProcessSample(i int) {
+	var (
+		begin, end = playbackRegion(s.begin.Read(i), s.end.Read(i), s.frames)
+		speed      = math.Abs(s.rate.Read(i) * math.Pow(2, s.pitch.Read(i)/12) * s.rateMult)
+		trigger    = s.trigger.Read(i)
+		loop       = s.cycle.Read(i) > 0
+		first      = int(math.Round(begin))
+		last       = int(math.Max(math.Round(end), float64(first+1)))
+	)
+	s.regionBegin.Store(int64(first))
+	s.regionEnd.Store(int64(last))
+	s.loop.Store(loop)
+
+	if isTrig(s.lastTrigger, trigger) {
+		s.request(first)
+	}
+	s.tick(speed, last, loop)
+
+	s.a.Write(i, s.frame[0])
+	s.b.Write(i, s.frame[1])
+	s.lastTrigger = trigger
+}
+
+// request asks the goroutine to start reading from a frame and restarts playback from it. The goroutine is started by
+// the first request.
+func (s *sampleStream) request(from int) {
+	if !s.started {
+		s.start()
+	}
+	s.playing = true
+	s.pos = 0
+	s.from = from
+	s.gen++
+
+	s.woken = 0
+	s.readPos.Store(0)
+	s.reqFrom.Store(int64(from))
+	s.reqGen.Store(s.gen)
+	s.signal()
+}
+
+// start starts the goroutine that reads the file, unless the stream has been closed.
+func (s *sampleStream) start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started || s.closed {
+		return
+	}
+	s.started = true
+	s.wg.Add(1)
+	go s.run()
+}
+
+// signal wakes the goroutine without waiting for it.
+func (s *sampleStream) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// tick writes the current frame to s.frame and advances playback. Playback holds its position, and outputs silence,
+// while the goroutine hasn't read far enough.
+func (s *sampleStream) tick(speed float64, last int, loop bool) {
+	for c := range s.frame {
+		s.frame[c] = 0
+	}
+	if !s.playing {
+		return
+	}
+
+	var (
+		j              = int(s.pos)
+		written, endAt = s.available()
+		headPos        = float64(s.from) + s.pos
+	)
+	switch {
+	case endAt >= 0 && s.pos > float64(endAt-1):
+		s.playing = false
+		return
+	case j+2 < written || (endAt >= 0 && written == endAt):
+		s.read(written)
+	case int(headPos)+2 < s.head.frames() && s.pos <= float64(last-s.from):
+		// The first pass through the region is available in memory.
+		for c := range s.frame {
+			if c < s.channels {
+				s.frame[c] = s.head.at(headPos, c)
+			}
+		}
+	case !loop && headPos > float64(last):
+		s.playing = false
+		return
+	default:
+		return
+	}
+
+	s.pos += speed
+	readPos := int64(math.Max(0, math.Floor(s.pos)-1))
+	s.readPos.Store(readPos)
+	if readPos-s.woken >= streamWakeFrames {
+		// Playback has freed enough of the ring for the goroutine to read another stretch of the file.
+		s.woken = readPos
+		s.signal()
+	}
+}
+
+// available returns the number of frames of the current request in the ring and, once the goroutine has reached the
+// end of the region without looping, the total number of frames in the request (otherwise -1).
+func (s *sampleStream) available() (written, endAt int) {
+	if s.ackGen.Load() != s.gen {
+		return 0, -1
+	}
+	return int(s.written.Load()), int(s.endAt.Load())
+}
+
+// read interpolates the current frame from the ring.
+func (s *sampleStream) read(written int) {
+	var (
+		j    = int(math.Floor(s.pos))
+		frac = s.pos - float64(j)
+	)
+	sample := func(k, c int) float64 {
+		if k < 0 {
+			k = 0
+		} else if k > written-1 {
+			k = written - 1
+		}
+		return s.ring[(k%streamRingFrames)*s.channels+c]
+	}
+	for c := range s.frame {
+		if c >= s.channels {
+			break
+		}
+		s.frame[c] = dsp.Hermite(sample(j-1, c), sample(j, c), sample(j+1, c), sample(j+2, c), frac)
+	}
+}
+
+// run reads the file into the ring ahead of playback until the stream is closed. It sleeps until it's woken by a new
+// request or by playback freeing space in the ring.
+func (s *sampleStream) run() {
+	defer s.wg.Done()
+
+	file, err := openAudioFile(s.path)
+	if err == nil {
+		defer file.Close()
+	}
+
+	var (
+		gen     uint64
+		cursor  int // next frame of the file to read
+		written int64
+		ended   = true
+		frames  = s.frames
+		block   = make([]float64, streamBlockFrames*s.channels)
+	)
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		}
+
+		if g := s.reqGen.Load(); g != gen {
+			gen = g
+			cursor = int(s.reqFrom.Load())
+			written = 0
+			// A file that can't be opened again plays silence.
+			ended = err != nil || file.seek(cursor) != nil
+			s.written.Store(0)
+			s.endAt.Store(-1)
+			if ended {
+				s.endAt.Store(0)
+			}
+			s.ackGen.Store(gen)
+		}
+
+		for !ended && s.reqGen.Load() == gen {
+			space := s.readPos.Load() + streamRingFrames - written
+			if space <= 0 {
+				break
+			}
+
+			var (
+				first = int(s.regionBegin.Load())
+				stop  = int(s.regionEnd.Load())
+				loop  = s.loop.Load()
+			)
+			if !loop {
+				stop++ // the last frame of the region is played when it isn't looped
+			}
+			if stop > frames {
+				stop = frames
+			}
+			if cursor >= stop {
+				if !loop || cursor == first {
+					ended = true
+					s.endAt.Store(written)
+					break
+				}
+				cursor = first
+				if err := file.seek(cursor); err != nil {
+					ended = true
+					s.endAt.Store(written)
+				}
+				continue
+			}
+
+			n := int(math.Min(float64(space), math.Min(streamBlockFrames, float64(stop-cursor))))
+			n, err := file.read(block[:n*s.channels])
+			if err == io.EOF {
+				// The file is shorter than it claimed to be.
+				frames = cursor
+				continue
+			} else if err != nil {
+				ended = true
+				s.endAt.Store(written)
+				break
+			}
+			for k := 0; k < n; k++ {
+				slot := int((written+int64(k))%streamRingFrames) * s.channels
+				copy(s.ring[slot:slot+s.channels], block[k*s.channels:(k+1)*s.channels])
+			}
+			written += int64(n)
+			cursor += n
+			s.written.Store(written)
+		}
+	}
+}
+
+// Close stops the goroutine reading the file, which closes the file.
+func (s *sampleStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.started {
+		close(s.done)
+		s.wg.Wait()
+	}
+	return nil
+}
+
+// readFull decodes frames from an audio file until dst is full.
+func readFull(af audioFile, dst []float64) error {
+	channels := af.format().channels
+	for len(dst) > 0 {
+		n, err := af.read(dst)
+		if err != nil {
+			return err
+		}
+		dst = dst[n*channels:]
+	}
+	return nil
+}
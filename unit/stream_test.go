@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const streamTestFrames = 3 * sampleRate // longer than the head held in memory
+
+func streamTestSamples() []float64 {
+	samples := make([]float64, streamTestFrames)
+	for i := range samples {
+		samples[i] = 0.5 + 0.4*math.Sin(float64(i)/100)
+	}
+	return samples
+}
+
+// waitStream waits until the stream has read far enough ahead to play a whole frame without running out.
+func waitStream(t *testing.T, s *sampleStream) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if written, endAt := s.available(); endAt >= 0 || written > int(s.pos)+2*frameSize+3 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("stream didn't catch up")
+}
+
+// playStream triggers playback and returns the first n samples of the first output.
+func playStream(t *testing.T, u *Unit, n int) []float64 {
+	var out []float64
+	for len(out) < n {
+		for i := 0; i < frameSize; i++ {
+			u.In["trigger"].Write(i, 1)
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			out = append(out, u.Out["a"].Out().Read(i))
+		}
+		waitStream(t, u.SampleProcessor.(*sampleStream))
+	}
+	return out[:n]
+}
+
+func TestSampleStream_MatchesMemory(t *testing.T) {
+	path := writeTestWAV(t, sampleRate, 1, streamTestSamples())
+	mem := newTestUnit(t, "sample", map[string]any{"file": path})
+	stream := newTestUnit(t, "sample", map[string]any{"file": path, "stream": true})
+
+	var memOut []float64
+	for len(memOut) < streamTestFrames {
+		for i := 0; i < frameSize; i++ {
+			mem.In["trigger"].Write(i, 1)
+			mem.In["rate"].Write(i, 1.5)
+			stream.In["rate"].Write(i, 1.5)
+		}
+		mem.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			memOut = append(memOut, mem.Out["a"].Out().Read(i))
+		}
+	}
+	streamOut := playStream(t, stream, streamTestFrames)
+
+	for i := range streamOut {
+		require.InDelta(t, memOut[i], streamOut[i], 1e-9, "sample %d", i)
+	}
+	require.Equal(t, 0.0, streamOut[len(streamOut)-1]) // finished playing
+}
+
+func TestSampleStream_BeyondHead(t *testing.T) {
+	samples := streamTestSamples()
+	u := newTestUnit(t, "sample", map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples), "stream": true})
+	for i := 0; i < frameSize; i++ {
+		u.In["begin"].Write(i, 0.9)
+	}
+
+	out := playStream(t, u, 4*frameSize)
+
+	// Playback waits in silence for the file to be read.
+	for len(out) > 0 && out[0] == 0 {
+		out = out[1:]
+	}
+	require.True(t, len(out) >= 2*frameSize)
+
+	first := int(math.Round(0.9 * float64(streamTestFrames-1)))
+	for i, v := range out {
+		require.InDelta(t, samples[first+i], v, 1e-4, "sample %d", i)
+	}
+}
+
+func TestSampleStream_Cycle(t *testing.T) {
+	samples := streamTestSamples()
+	u := newTestUnit(t, "sample", map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples), "stream": true})
+	for i := 0; i < frameSize; i++ {
+		u.In["begin"].Write(i, 0.9)
+		u.In["end"].Write(i, 0.95)
+		u.In["cycle"].Write(i, 1)
+	}
+
+	out := playStream(t, u, 20000)
+	for len(out) > 0 && out[0] == 0 {
+		out = out[1:]
+	}
+
+	var (
+		first  = int(math.Round(0.9 * float64(streamTestFrames-1)))
+		last   = int(math.Round(0.95 * float64(streamTestFrames-1)))
+		length = last - first
+	)
+	require.True(t, len(out) > 2*length)
+	for i, v := range out {
+		require.InDelta(t, samples[first+i%length], v, 1e-4, "sample %d", i)
+	}
+}
+
+func TestSampleStream_FLAC(t *testing.T) {
+	samples := streamTestSamples()
+	u := newTestUnit(t, "sample", map[string]any{"file": writeTestFLAC(t, sampleRate, 1, samples), "stream": true})
+	for i := 0; i < frameSize; i++ {
+		u.In["begin"].Write(i, 0.95)
+	}
+
+	out := playStream(t, u, 8000)
+	for len(out) > 0 && out[0] == 0 {
+		out = out[1:]
+	}
+
+	first := int(math.Round(0.95 * float64(streamTestFrames-1)))
+	require.True(t, len(out) > streamTestFrames-first)
+	for i, v := range out[:streamTestFrames-first] {
+		require.InDelta(t, samples[first+i], v, 1e-4, "sample %d", i)
+	}
+	require.Equal(t, 0.0, out[len(out)-1])
+}
+
+func TestSampleStream_StartsOnTrigger(t *testing.T) {
+	u := newTestUnit(t, "sample", map[string]any{"file": writeTestWAV(t, sampleRate, 1, streamTestSamples()), "stream": true})
+	s := u.SampleProcessor.(*sampleStream)
+
+	// Nothing is read from the file, or left open, until playback is triggered.
+	u.ProcessFrame(frameSize)
+	require.False(t, s.started)
+
+	playStream(t, u, frameSize)
+	require.True(t, s.started)
+	require.NoError(t, u.Close())
+	select {
+	case <-s.done:
+	default:
+		t.Fatal("goroutine not stopped")
+	}
+
+	// Playback can't be reversed or crossfaded.
+	require.NotContains(t, u.In, "direction")
+	require.NotContains(t, u.In, "crossfade")
+}
+
+func TestSampleStream_InvalidConfig(t *testing.T) {
+	bs := NewBuffers()
+	bs.Set("x", &Buffer{Data: []float64{0, 1}, Channels: 1})
+	_, err := Builders()["sample"](Config{Values: map[string]any{"buffer": "x", "stream": true}, Buffers: bs})
+	require.Error(t, err)
+	require.Equal(t, "unit/sample: buffers are held in memory and can't be streamed", err.Error())
+
+	_, err = Builders()["sample"](Config{Values: map[string]any{"stream": true}})
+	require.Error(t, err)
+	require.Equal(t, "unit/sample: streaming requires a file", err.Error())
+}
@@ -1,5 +1,22 @@
 package unit
 
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-audio/aiff"
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+	"github.com/mewkiz/flac"
+	"github.com/mewkiz/flac/frame"
+	"github.com/mewkiz/flac/meta"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/randtest"
+)
+
 type noopSampleProc struct{}
 
 func (p noopSampleProc) ProcessSample(i int) {}
@@ -31,6 +48,17 @@ func (p sampleProcessor) ProcessSample(i int) {
 	}
 }
 
+type controlProcessor struct {
+	sampleProcessor
+	control func(int)
+}
+
+func (p controlProcessor) ProcessControl(n int) {
+	if p.control != nil {
+		p.control(n)
+	}
+}
+
 type closer struct {
 	fn func() error
 }
@@ -70,3 +98,115 @@ type outProcessorCloser struct {
 	closer
 	outProcessor
 }
+
+// newTestUnit builds a Unit of a type from configuration values, failing the test if it can't be built. The Unit is
+// closed when the test finishes.
+func newTestUnit(t *testing.T, typ string, values map[string]any) *Unit {
+	u, err := Builders()[typ](Config{
+		Values:     values,
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, u.Close()) })
+	return u
+}
+
+// writeTestWAV writes interleaved samples in the range [-1, 1] to a 16-bit WAV file in a temporary directory.
+func writeTestWAV(t *testing.T, rate, channels int, samples []float64) string {
+	path := filepath.Join(t.TempDir(), "test.wav")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	data := make([]int, len(samples))
+	for i, s := range samples {
+		data[i] = int(math.Round(s * 32767))
+	}
+	enc := wav.NewEncoder(f, rate, 16, channels, 1)
+	require.NoError(t, enc.Write(&audio.IntBuffer{
+		Format:         &audio.Format{NumChannels: channels, SampleRate: rate},
+		Data:           data,
+		SourceBitDepth: 16,
+	}))
+	require.NoError(t, enc.Close())
+	return path
+}
+
+// writeTestAIFF writes interleaved samples in the range [-1, 1] to a 24-bit AIFF file in a temporary directory.
+func writeTestAIFF(t *testing.T, rate, channels int, samples []float64) string {
+	path := filepath.Join(t.TempDir(), "test.aiff")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	data := make([]int, len(samples))
+	for i, s := range samples {
+		data[i] = int(math.Round(s * (1<<23 - 1)))
+	}
+	enc := aiff.NewEncoder(f, rate, 24, channels)
+	require.NoError(t, enc.Write(&audio.IntBuffer{
+		Format:         &audio.Format{NumChannels: channels, SampleRate: rate},
+		Data:           data,
+		SourceBitDepth: 24,
+	}))
+	require.NoError(t, enc.Close())
+	return path
+}
+
+// writeTestFLAC writes interleaved samples in the range [-1, 1] to a 16-bit FLAC file in a temporary directory.
+func writeTestFLAC(t *testing.T, rate, channels int, samples []float64) string {
+	path := filepath.Join(t.TempDir(), "test.flac")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	const blockSize = 1024
+	frames := len(samples) / channels
+	enc, err := flac.NewEncoder(f, &meta.StreamInfo{
+		BlockSizeMin:  blockSize,
+		BlockSizeMax:  blockSize,
+		SampleRate:    uint32(rate),
+		NChannels:     uint8(channels),
+		BitsPerSample: 16,
+		NSamples:      uint64(frames),
+	})
+	require.NoError(t, err)
+
+	layout := frame.ChannelsMono
+	if channels == 2 {
+		layout = frame.ChannelsLR
+	}
+	for offset := 0; offset < frames; offset += blockSize {
+		size := blockSize
+		if offset+size > frames {
+			size = frames - offset
+		}
+		fr := &frame.Frame{
+			Header: frame.Header{
+				HasFixedBlockSize: true,
+				BlockSize:         uint16(size),
+				SampleRate:        uint32(rate),
+				Channels:          layout,
+				BitsPerSample:     16,
+				Num:               uint64(offset / blockSize),
+			},
+			Subframes: make([]*frame.Subframe, channels),
+		}
+		for c := range fr.Subframes {
+			data := make([]int32, size)
+			for i := range data {
+				data[i] = int32(math.Round(samples[(offset+i)*channels+c] * 32767))
+			}
+			fr.Subframes[c] = &frame.Subframe{
+				SubHeader: frame.SubHeader{Pred: frame.PredVerbatim},
+				Samples:   data,
+				NSamples:  size,
+			}
+		}
+		require.NoError(t, enc.WriteFrame(fr))
+	}
+	require.NoError(t, enc.Close())
+	return path
+}
@@ -19,14 +19,14 @@ func newSwitch(io *IO, c Config) (*Unit, error) {
 
 	inputs := make([]*In, config.Size)
 	for i := range inputs {
-		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0))
+		inputs[i] = io.NewIn(fmt.Sprintf("%d", i), dsp.Float64(0), WithDoc("signal to step through"))
 	}
 
 	s := &seqSwitch{
-		trigger:   io.NewIn("trigger", dsp.Float64(-1)),
-		reset:     io.NewIn("reset", dsp.Float64(0)),
+		trigger:   io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("selects the next input")),
+		reset:     io.NewIn("reset", dsp.Float64(0), WithMeasure(MeasureTrigger), WithDoc("selects the first input")),
 		inputs:    inputs,
-		out:       io.NewOut("out"),
+		out:       io.NewOut("out", WithDoc("selected input")),
 		lastClock: -1,
 		lastReset: -1,
 	}
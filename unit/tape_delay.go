@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultTapeHeads   = 3
+	maxTapeHeads       = 4
+	maxTapeDelayMS     = 4000
+	tapeSlewMS         = 150 // time taken by the tape to change speed
+	tapeWowRate        = 0.7 // Hz
+	tapeWowDepthMS     = 3
+	tapeFlutterRate    = 9 // Hz
+	tapeFlutterDepthMS = 0.15
+)
+
+func newTapeDelay(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Heads    int
+		PingPong bool
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Heads == 0 {
+		config.Heads = defaultTapeHeads
+	} else if config.Heads < 1 || config.Heads > maxTapeHeads {
+		return nil, c.errorf("heads must be between 1 and %d, got %d", maxTapeHeads, config.Heads)
+	}
+
+	var (
+		maxDelay     = dsp.Duration(maxTapeDelayMS, c.SampleRate).Float64()
+		wowDepth     = dsp.Duration(tapeWowDepthMS, c.SampleRate).Float64()
+		flutterDepth = dsp.Duration(tapeFlutterDepthMS, c.SampleRate).Float64()
+		slew         = dsp.Duration(tapeSlewMS, c.SampleRate).Float64()
+		size         = int(maxDelay+wowDepth+flutterDepth) + 3
+	)
+	t := &tapeDelay{
+		in:       io.NewIn("in", dsp.Float64(0), WithDoc("signal to record to the tape")),
+		time:     io.NewIn("time", dsp.Duration(300, c.SampleRate), WithRange(0, maxTapeDelayMS), WithDoc("delay of the last playback head; the others are spaced evenly before it")),
+		feedback: io.NewIn("feedback", dsp.Float64(0.4), WithRange(0, 1.2), WithDoc("amount of the playback recorded back to the tape; above 1 it runs away into saturation")),
+		wow:      io.NewIn("wow", dsp.Float64(0.2), WithRange(0, 1), WithDoc("amount of slow variation in the tape's speed")),
+		flutter:  io.NewIn("flutter", dsp.Float64(0.2), WithRange(0, 1), WithDoc("amount of fast variation in the tape's speed")),
+		drive:    io.NewIn("drive", dsp.Float64(1), WithRange(0.1, 10), WithDoc("how hard the tape saturates")),
+		tone:     io.NewIn("tone", dsp.Frequency(4000, c.SampleRate), WithRange(0, float64(c.SampleRate)/2), WithDoc("cutoff of the high frequency loss in the feedback path")),
+		mix:      io.NewIn("mix", dsp.Float64(0), WithRange(-1, 1), WithDoc("balance between dry (-1) and wet (1)")),
+		fbreturn: io.NewIn("fb-return", dsp.Float64(0), WithDoc("feedback returned from processing patched to fb-send")),
+		aOut:     io.NewOut("a", WithDoc("left output")),
+		bOut:     io.NewOut("b", WithDoc("right output")),
+		fbsend:   io.NewOut("fb-send", WithDoc("playback sent out for processing in the feedback path")),
+		levels:   make([]*In, config.Heads),
+		pingPong: config.PingPong,
+		tapes:    []*tape{newTape(size, config.Heads, slew)},
+
+		maxDelay:     maxDelay,
+		wowDepth:     wowDepth,
+		flutterDepth: flutterDepth,
+		wowFreq:      dsp.Frequency(tapeWowRate, c.SampleRate).Float64(),
+		flutterFreq:  dsp.Frequency(tapeFlutterRate, c.SampleRate).Float64(),
+	}
+	if config.PingPong {
+		t.tapes = append(t.tapes, newTape(size, config.Heads, slew))
+	}
+	for i := range t.levels {
+		t.levels[i] = io.NewIn(fmt.Sprintf("%d/level", i), dsp.Float64(1), WithDoc("level of the playback head"))
+	}
+	return NewUnit(io, t), nil
+}
+
+// tapeDelay is a tape echo. The input is recorded to a loop of tape, saturating as it goes, and played back by several
+// heads spaced along the tape. The playback is recorded back to the tape through a filter that loses the high end
+// with every pass. What is recorded back is scaled down by the sum of the head levels when it exceeds 1, so the
+// feedback alone decides whether the echoes die away or run away. The tape's speed wavers with wow and flutter and changes gradually when the time changes.
+//
+// In ping-pong mode there are two loops of tape, each recording the other's playback, and only the left one records
+// the input, so echoes alternate between the outputs. Only the echoes returning to the left loop are sent to fb-send.
+type tapeDelay struct {
+	in, time, feedback, wow, flutter, drive, tone, mix, fbreturn *In
+	aOut, bOut, fbsend                                           *Out
+	levels                                                       []*In
+
+	pingPong bool
+	tapes    []*tape
+
+	maxDelay, wowDepth, flutterDepth float64
+	wowFreq, flutterFreq             float64
+	wowPhase, flutterPhase           float64
+}
+
+func (t *tapeDelay) ProcessSample(i int) {
+	var (
+		in       = t.in.Read(i)
+		feedback = dsp.Clamp(t.feedback.Read(i), 0, 1.2)
+		drive    = dsp.Clamp(t.drive.Read(i), 0.1, 10)
+		tone     = t.tone.Read(i)
+		mix      = t.mix.Read(i)
+		wobble   = dsp.Clamp(t.wow.Read(i), 0, 1)*t.wowDepth*dsp.Sin(t.wowPhase) +
+			dsp.Clamp(t.flutter.Read(i), 0, 1)*t.flutterDepth*dsp.Sin(t.flutterPhase)
+		spacing = dsp.Clamp(t.time.Read(i), 0, t.maxDelay) / float64(len(t.levels))
+	)
+	advanceLFO(&t.wowPhase, t.wowFreq)
+	advanceLFO(&t.flutterPhase, t.flutterFreq)
+
+	// The left loop records the playback returning to it: its own, or the right loop's in ping-pong mode.
+	var (
+		left, right = t.tapes[0], t.tapes[len(t.tapes)-1]
+		toLeft      = right.returned
+	)
+	if t.fbsend.DestinationCount() > 0 {
+		t.fbsend.Write(i, toLeft)
+		toLeft = t.fbreturn.Read(i)
+	}
+	left.record(in+left.loss(toLeft*feedback, tone), drive)
+	if t.pingPong {
+		right.record(right.loss(left.returned*feedback, tone), drive)
+	}
+
+	for _, tp := range t.tapes {
+		tp.play(spacing, wobble, t.levels, i)
+	}
+	t.aOut.Write(i, dsp.Mix(mix, in, left.last))
+	t.bOut.Write(i, dsp.Mix(mix, in, right.last))
+}
+
+// tape is a loop of tape with its playback heads.
+type tape struct {
+	dl    *dsp.DelayLine
+	heads []dsp.DelayTap
+	lp    dsp.Biquad
+	block dsp.DCBlock
+	last  float64 // playback at the previous sample
+
+	// returned is the playback at the previous sample scaled down by the sum of the head levels, if above 1.
+	returned float64
+}
+
+func newTape(size, heads int, slew float64) *tape {
+	t := &tape{
+		dl:    dsp.NewDelayLine(size),
+		heads: make([]dsp.DelayTap, heads),
+		lp:    dsp.Biquad{Type: dsp.HighCut, Q: math.Sqrt2 / 2},
+	}
+	for i := range t.heads {
+		t.heads[i] = dsp.DelayTap{Interpolation: dsp.InterpolateCubic, Slew: slew}
+	}
+	return t
+}
+
+// loss filters the signal recorded back to the tape, losing high frequencies and DC.
+func (t *tape) loss(v, cutoff float64) float64 {
+	t.lp.Cutoff = cutoff
+	return t.block.Tick(t.lp.Tick(v))
+}
+
+// record saturates a signal and records it to the tape.
+func (t *tape) record(v, drive float64) {
+	t.dl.Write(math.Tanh(drive*v) / drive)
+}
+
+// play mixes the playback of the heads, which are spaced evenly along the tape.
+func (t *tape) play(spacing, wobble float64, levels []*In, i int) {
+	var out, sum float64
+	for h := range t.heads {
+		// After a write, position 1 holds the sample just recorded.
+		pos := 1 + math.Max(spacing*float64(h+1)+wobble, 0)
+		level := levels[h].Read(i)
+		out += t.heads[h].Read(t.dl, pos) * level
+		sum += math.Abs(level)
+	}
+	t.last = out
+	t.returned = out / math.Max(sum, 1)
+}
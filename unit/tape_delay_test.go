@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+// runTapeDelay feeds a signal, given per sample, to the unit and returns n samples of each output.
+func runTapeDelay(u *Unit, inputs map[string]float64, n int, signal func(int) float64) (a, b []float64) {
+	for len(a) < n {
+		for i := 0; i < frameSize; i++ {
+			u.In["in"].Write(i, signal(len(a)+i))
+			for name, v := range inputs {
+				u.In[name].Write(i, v)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			a = append(a, u.Out["a"].Out().Read(i))
+			b = append(b, u.Out["b"].Out().Read(i))
+		}
+	}
+	return a[:n], b[:n]
+}
+
+func quietImpulse(n int) float64 {
+	if n == 0 {
+		return 0.01
+	}
+	return 0
+}
+
+func TestTapeDelay_Heads(t *testing.T) {
+	u := newTestUnit(t, "tape-delay", map[string]any{"heads": 2})
+	a, b := runTapeDelay(u, map[string]float64{
+		"mix":      1,
+		"time":     dsp.Duration(100, sampleRate).Float64(),
+		"wow":      0,
+		"flutter":  0,
+		"feedback": 0,
+		"1/level":  0.5,
+	}, 5000, quietImpulse)
+
+	for j := range a {
+		var expected float64
+		switch j {
+		case 2205:
+			expected = 0.01
+		case 4410:
+			expected = 0.005
+		}
+		require.InDelta(t, expected, a[j], 1e-6, "sample %d", j)
+		require.Equal(t, a[j], b[j])
+	}
+}
+
+func TestTapeDelay_Feedback(t *testing.T) {
+	// Each pass through the tape loses some of the high end.
+	u := newTestUnit(t, "tape-delay", map[string]any{"heads": 1})
+	a, _ := runTapeDelay(u, map[string]float64{
+		"mix":      1,
+		"time":     1000,
+		"wow":      0,
+		"flutter":  0,
+		"feedback": 1,
+	}, 3100, quietImpulse)
+	require.InDelta(t, 0.01, a[1000], 1e-6)
+	var second float64
+	for _, v := range a[2000:2100] {
+		second = math.Max(second, math.Abs(v))
+	}
+	require.True(t, second > 0.001 && second < 0.01, "second echo peaks at %f", second)
+}
+
+func TestTapeDelay_Decays(t *testing.T) {
+	// With every head at full level, the echoes of a burst still die away at the default feedback.
+	u := newTestUnit(t, "tape-delay", nil)
+	burst := func(n int) float64 {
+		if n < 100 {
+			return 0.5
+		}
+		return 0
+	}
+	a, _ := runTapeDelay(u, map[string]float64{"mix": 1}, 10*sampleRate, burst)
+
+	var peaks []float64
+	for s := 0; s < len(a); s += sampleRate {
+		var p float64
+		for _, v := range a[s : s+sampleRate] {
+			p = math.Max(p, math.Abs(v))
+		}
+		peaks = append(peaks, p)
+	}
+	for j := 1; j < len(peaks); j++ {
+		require.True(t, peaks[j] < peaks[j-1], "second %d peaks at %f, after %f", j, peaks[j], peaks[j-1])
+	}
+	require.True(t, peaks[len(peaks)-1] < 0.05, "last second peaks at %f", peaks[len(peaks)-1])
+}
+
+func TestTapeDelay_PingPong(t *testing.T) {
+	u := newTestUnit(t, "tape-delay", map[string]any{"heads": 1, "pingpong": true})
+	a, b := runTapeDelay(u, map[string]float64{
+		"mix":      1,
+		"time":     1000,
+		"wow":      0,
+		"flutter":  0,
+		"feedback": 1,
+		"tone":     0.45,
+	}, 3100, quietImpulse)
+
+	peak := func(s []float64) float64 {
+		var p float64
+		for _, v := range s {
+			p = math.Max(p, math.Abs(v))
+		}
+		return p
+	}
+	require.InDelta(t, 0.01, peak(a[900:1100]), 1e-6)
+	require.InDelta(t, 0, peak(b[900:1100]), 1e-6)
+	require.InDelta(t, 0, peak(a[1900:2100]), 1e-6)
+	require.True(t, peak(b[1900:2100]) > 0.005)
+	require.True(t, peak(a[2900:3100]) > 0.005)
+}
+
+func TestTapeDelay_Wow(t *testing.T) {
+	// The tape's wavering speed shifts the echoes of a steady sine back and forth.
+	sine := func(n int) float64 { return math.Sin(float64(n) * 0.05) }
+	echo := func(wow float64) []float64 {
+		u := newTestUnit(t, "tape-delay", nil)
+		a, _ := runTapeDelay(u, map[string]float64{"mix": 1, "wow": wow, "flutter": 0, "feedback": 0}, 40000, sine)
+		return a
+	}
+	var (
+		steady = echo(0)
+		wavery = echo(1)
+		diff   float64
+	)
+	for j := 20000; j < 40000; j++ {
+		diff = math.Max(diff, math.Abs(steady[j]-wavery[j]))
+	}
+	require.True(t, diff > 0.1)
+}
+
+func TestTapeDelay_InvalidConfig(t *testing.T) {
+	_, err := Builders()["tape-delay"](Config{Values: map[string]any{"heads": 5}})
+	require.EqualError(t, err, "unit/tape-delay: heads must be between 1 and 4, got 5")
+}
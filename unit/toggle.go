@@ -6,8 +6,8 @@ import (
 
 func newToggle(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &toggle{
-		trigger: io.NewIn("trigger", dsp.Float64(-1)),
-		out:     io.NewOut("out"),
+		trigger: io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("flips the output")),
+		out:     io.NewOut("out", WithMeasure(MeasureGate), WithDoc("toggled gate")),
 	}), nil
 }
 
@@ -8,9 +8,9 @@ import (
 
 func newTranspose(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &transpose{
-		in:        io.NewIn("in", dsp.Float64(0)),
-		semitones: io.NewIn("semitones", dsp.Float64(0)),
-		out:       io.NewOut("out"),
+		in:        io.NewIn("in", dsp.Float64(0), WithMeasure(MeasureHz), WithDoc("frequency to transpose")),
+		semitones: io.NewIn("semitones", dsp.Float64(0), WithMeasure(MeasureSemitones), WithDoc("amount to transpose")),
+		out:       io.NewOut("out", WithMeasure(MeasureHz), WithDoc("transposed frequency")),
 	}), nil
 }
 
@@ -19,10 +19,10 @@ var perfectFirst = musictheory.Perfect(1)
 
 func newTransposeInterval(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &transposeInterval{
-		in:       io.NewIn("in", dsp.Float64(0)),
-		quality:  io.NewIn("quality", dsp.Float64(0)),
-		step:     io.NewIn("step", dsp.Float64(0)),
-		out:      io.NewOut("out"),
+		in:       io.NewIn("in", dsp.Float64(0), WithMeasure(MeasureHz), WithDoc("frequency to transpose")),
+		quality:  io.NewIn("quality", dsp.Float64(0), WithMeasure(MeasureMode), WithRange(0, 4), WithDoc("perfect, minor, major, diminished or augmented")),
+		step:     io.NewIn("step", dsp.Float64(0), WithMeasure(MeasureInteger), WithDoc("scale step of the interval")),
+		out:      io.NewOut("out", WithMeasure(MeasureHz), WithDoc("transposed frequency")),
 		interval: perfectFirst,
 	}), nil
 }
@@ -8,8 +8,8 @@ import (
 
 func newUnary(io *IO, op unaryOp) (*Unit, error) {
 	return NewUnit(io, &unary{
-		x:   io.NewIn("x", dsp.Float64(0)),
-		out: io.NewOut("out"),
+		x:   io.NewIn("x", dsp.Float64(0), WithDoc("operand")),
+		out: io.NewOut("out", WithDoc("result of the operation")),
 		op:  op,
 	}), nil
 }
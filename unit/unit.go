@@ -18,6 +18,14 @@ type SampleProcessor interface {
 	ProcessSample(i int)
 }
 
+// ControlProcessor is implemented by processors that can be processed at control rate. ProcessControl is called once
+// per frame of n samples. It reads its inputs and writes its outputs at the first sample of the frame, advancing its
+// internal state as though n samples have elapsed. Processors that don't implement it can't be processed at control
+// rate; ticking them once per frame would slow down anything they time in samples.
+type ControlProcessor interface {
+	ProcessControl(n int)
+}
+
 // CondProcessor informs another party whether or not it should be processed.
 type CondProcessor interface {
 	IsProcessable() bool
@@ -36,13 +44,59 @@ const (
 type Unit struct {
 	*IO
 	SampleProcessor
-	rate Rate
-	node *graph.Node
+	rate  Rate
+	node  *graph.Node
+	ramps []*In
 }
 
 // NewUnit creates a new Unit that defaults to audio rate.
 func NewUnit(io *IO, p SampleProcessor) *Unit {
-	return &Unit{IO: io, SampleProcessor: p}
+	u := &Unit{IO: io, SampleProcessor: p}
+	for _, in := range io.In {
+		in.unit = u
+	}
+	for _, o := range io.Out {
+		o.Out().unit = u
+	}
+	return u
+}
+
+// Rate returns the rate the Unit is processed at.
+func (u *Unit) Rate() Rate {
+	return u.rate
+}
+
+// SetRate sets the rate the Unit is processed at. Control rate is only available to Units whose processor, or all of
+// whose output processors, implement ControlProcessor.
+func (u *Unit) SetRate(r Rate) error {
+	switch r {
+	case RateAudio:
+	case RateControl:
+		if !u.SupportsControlRate() {
+			return errors.Errorf("unit/%s does not support control rate", u.Type)
+		}
+	default:
+		return errors.Errorf("unit/%s: unknown rate %d", u.Type, r)
+	}
+	u.rate = r
+	return nil
+}
+
+// SupportsControlRate returns whether or not the Unit can be processed at control rate.
+func (u *Unit) SupportsControlRate() bool {
+	if u.SampleProcessor != nil {
+		_, ok := u.SampleProcessor.(ControlProcessor)
+		return ok
+	}
+	if len(u.Out) == 0 {
+		return false
+	}
+	for _, o := range u.Out {
+		if _, ok := o.(ControlProcessor); !ok {
+			return false
+		}
+	}
+	return true
 }
 
 // IsProcessable determines whether or not this Unit's ProcessFrame method should be called by the engine
@@ -52,12 +106,16 @@ func (u *Unit) IsProcessable() bool {
 
 // ProcessFrame calculates a block of samples.
 func (u *Unit) ProcessFrame(n int) {
-	if p, ok := u.SampleProcessor.(FrameProcessor); ok {
-		p.ProcessFrame(n)
+	u.AdvanceRamps(n)
+	if u.rate == RateControl {
+		u.SampleProcessor.(ControlProcessor).ProcessControl(n)
+		for _, o := range u.Out {
+			o.Out().expand(n)
+		}
 		return
 	}
-	if u.rate == RateControl {
-		u.ProcessSample(0)
+	if p, ok := u.SampleProcessor.(FrameProcessor); ok {
+		p.ProcessFrame(n)
 		return
 	}
 	for i := 0; i < n; i++ {
@@ -65,6 +123,33 @@ func (u *Unit) ProcessFrame(n int) {
 	}
 }
 
+// AdvanceRamps moves any in-progress input ramps forward by n samples. ProcessFrame calls this automatically; it only
+// needs to be called by parties that drive ProcessSample directly, or that process a Unit through its outputs.
+func (u *Unit) AdvanceRamps(n int) {
+	if len(u.ramps) == 0 {
+		return
+	}
+	active := u.ramps[:0]
+	for _, in := range u.ramps {
+		if in.advanceRamp(n) {
+			active = append(active, in)
+		}
+	}
+	for i := len(active); i < len(u.ramps); i++ {
+		u.ramps[i] = nil
+	}
+	u.ramps = active
+}
+
+func (u *Unit) addRamp(in *In) {
+	for _, r := range u.ramps {
+		if r == in {
+			return
+		}
+	}
+	u.ramps = append(u.ramps, in)
+}
+
 // Close closes the Processor if it is an io.Closer. It also closes any Outs that it has.
 func (u *Unit) Close() error {
 	if c, ok := u.SampleProcessor.(io.Closer); ok {
@@ -2,12 +2,15 @@ package unit
 
 import (
 	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/brettbuddin/shaden/dsp"
 	"github.com/brettbuddin/shaden/graph"
+	"github.com/brettbuddin/shaden/lisp"
+	"github.com/brettbuddin/shaden/randtest"
 )
 
 const sampleRate = 44100.0
@@ -21,6 +24,30 @@ func TestRegisteredNames(t *testing.T) {
 	}
 }
 
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, "example")
+	})
+
+	b := func(io *IO, c Config) (*Unit, error) {
+		io.NewOut("out")
+		return NewUnit(io, nil), nil
+	}
+	require.NoError(t, Register("example", b))
+	require.EqualError(t, Register("example", b), `duplicate unit named "example"`)
+	require.EqualError(t, Register("gen", b), `duplicate unit named "gen"`)
+	require.EqualError(t, Register("midi-input", b), `duplicate unit named "midi-input"`)
+
+	builder, ok := RegisteredBuilders()["example"]
+	require.True(t, ok)
+	u, err := builder(Config{FrameSize: frameSize})
+	require.NoError(t, err)
+	require.Equal(t, "example", u.Type)
+	require.Contains(t, u.Out, "out")
+}
+
 func TestUnit_GraphAttachment(t *testing.T) {
 	io := NewIO("example", frameSize)
 	io.NewIn("in", dsp.Float64(0))
@@ -83,19 +110,88 @@ func TestUnit_ProxyProcessFrame(t *testing.T) {
 func TestUnit_ProcessFrameHonorRateSetting(t *testing.T) {
 	var (
 		sampleProcCalled bool
+		controlPeriod    int
 
 		io        = NewIO("example", frameSize)
-		processor = sampleProcessor{fn: func(i int) {
-			require.Equal(t, 0, i)
-		}}
+		out       = io.NewOut("out")
+		processor = controlProcessor{
+			sampleProcessor: sampleProcessor{fn: func(i int) {
+				sampleProcCalled = true
+			}},
+			control: func(n int) {
+				controlPeriod = n
+				out.Write(0, 1)
+			},
+		}
 		u = NewUnit(io, processor)
 		g = graph.New()
 	)
-	u.rate = RateControl
+	require.NoError(t, u.SetRate(RateControl))
 	err := u.Attach(g)
 	require.NoError(t, err)
 	u.ProcessFrame(10)
 	require.False(t, sampleProcCalled)
+	require.Equal(t, 10, controlPeriod)
+	require.Equal(t, 0.1, out.Read(0))
+	require.Equal(t, 0.5, out.Read(4))
+	require.Equal(t, 1.0, out.Read(9))
+}
+
+func TestUnit_SetRate(t *testing.T) {
+	u := NewUnit(NewIO("example", frameSize), sampleProcessor{})
+	require.EqualError(t, u.SetRate(RateControl), "unit/example does not support control rate")
+	require.NoError(t, u.SetRate(RateAudio))
+
+	builder := Builders()["adsr"]
+	u, err := builder(Config{
+		Values:     map[string]any{"rate": "control"},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RateControl, u.Rate())
+
+	u, err = builder(Config{
+		Values:     map[string]any{"rate": lisp.Keyword("control")},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RateControl, u.Rate())
+
+	u, err = Builders()["low-gen"](Config{
+		Values:     map[string]any{"rate": int(RateAudio)},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RateAudio, u.Rate())
+
+	_, err = Builders()["adsr"](Config{
+		Values:     map[string]any{"rate": 1.5},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/adsr: invalid rate 1.5 (expected audio or control)")
+
+	_, err = Builders()["filter"](Config{
+		Values:     map[string]any{"rate": "control"},
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.EqualError(t, err, "unit/filter: rate is only accepted by units that can be processed at control rate (adsr, low-gen, slope)")
+}
+
+func TestControlRateUnits(t *testing.T) {
+	for name, builder := range Builders() {
+		u, err := builder(Config{Rand: randtest.Static(), SampleRate: sampleRate, FrameSize: frameSize})
+		if err != nil {
+			continue // needs configuration to be built
+		}
+		require.Equal(t, slices.Contains(controlRateUnits, name), u.SupportsControlRate(), name)
+		require.NoError(t, u.Close())
+	}
 }
 
 func TestUnit_ExternalNeighborCount(t *testing.T) {
@@ -8,8 +8,8 @@ import (
 
 func newValToGate(io *IO, _ Config) (*Unit, error) {
 	return NewUnit(io, &valToGate{
-		in:  io.NewIn("in", dsp.Float64(0)),
-		out: io.NewOut("out"),
+		in:  io.NewIn("in", dsp.Float64(0), WithDoc("signal to convert")),
+		out: io.NewOut("out", WithMeasure(MeasureGate), WithDoc("high while the signal is positive")),
 	}), nil
 }
 
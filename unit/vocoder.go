@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	defaultVocoderBands = 16
+	minVocoderBands     = 4
+	maxVocoderBands     = 64
+	vocoderLowest       = 100.0  // Hz
+	vocoderHighest      = 8000.0 // Hz
+	vocoderMaxCutoff    = 0.45   // fraction of the sample rate
+)
+
+func newVocoder(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		Bands int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Bands == 0 {
+		config.Bands = defaultVocoderBands
+	} else if config.Bands < minVocoderBands || config.Bands > maxVocoderBands {
+		return nil, c.errorf("bands must be between %d and %d, got %d", minVocoderBands, maxVocoderBands, config.Bands)
+	}
+
+	v := &vocoder{
+		carrier:   io.NewIn("carrier", dsp.Float64(0), WithDoc("signal shaped by the modulator, such as a pad")),
+		modulator: io.NewIn("modulator", dsp.Float64(0), WithDoc("signal whose spectrum shapes the carrier, such as speech")),
+		shift:     io.NewIn("shift", dsp.Float64(0), WithMeasure(MeasureInteger), WithRange(-maxVocoderBands, maxVocoderBands), WithDoc("number of bands by which the modulator's bands are moved up the carrier's")),
+		formant:   io.NewIn("formant", dsp.Float64(0), WithMeasure(MeasureSemitones), WithRange(-24, 24), WithDoc("shift of the carrier's bands in frequency")),
+		noise:     io.NewIn("noise", dsp.Float64(0), WithRange(0, 1), WithDoc("amount of noise added to the carrier, so unvoiced sounds like sibilants come through")),
+		attack:    io.NewIn("attack", dsp.Duration(5, c.SampleRate), WithRange(0, 500), WithDoc("time taken by a band to follow a rise in the modulator")),
+		release:   io.NewIn("release", dsp.Duration(30, c.SampleRate), WithRange(0, 2000), WithDoc("time taken by a band to follow a fall in the modulator")),
+		out:       io.NewOut("out", WithDoc("carrier shaped by the modulator")),
+		rand:      c.Rand,
+		freqs:     make([]float64, config.Bands),
+		modBands:  make([]vocoderBand, config.Bands),
+		carBands:  make([]vocoderBand, config.Bands),
+		envelopes: make([]envelopeFollower, config.Bands),
+	}
+
+	// Bands are spread evenly in pitch, each as wide as the distance between them.
+	var (
+		octaves = math.Log2(vocoderHighest / vocoderLowest)
+		width   = octaves / float64(config.Bands-1)
+		q       = 1 / (2 * math.Sinh(math.Ln2/2*width))
+	)
+	for i := range v.freqs {
+		v.freqs[i] = dsp.Frequency(vocoderLowest*math.Exp2(width*float64(i)), c.SampleRate).Float64()
+		v.modBands[i] = newVocoderBand(v.freqs[i], q)
+		v.carBands[i] = newVocoderBand(v.freqs[i], q)
+	}
+	return NewUnit(io, v), nil
+}
+
+// vocoder is a channel vocoder. The modulator and carrier are each split into matching banks of band-pass filters, and
+// each of the carrier's bands is shaped by the envelope of the modulator's band at the same place.
+type vocoder struct {
+	carrier, modulator, shift, formant, noise, attack, release *In
+	out                                                        *Out
+	rand                                                       *rand.Rand
+
+	freqs              []float64
+	modBands, carBands []vocoderBand
+	envelopes          []envelopeFollower
+}
+
+func (v *vocoder) ProcessSample(i int) {
+	var (
+		modulator = v.modulator.Read(i)
+		carrier   = v.carrier.Read(i)
+		shift     = v.shift.ReadSlowInt(i, clampInt(-maxVocoderBands, maxVocoderBands))
+		formant   = math.Exp2(v.formant.Read(i) / 12)
+		noise     = dsp.Clamp(v.noise.Read(i), 0, 1)
+		attack    = v.attack.Read(i)
+		release   = v.release.Read(i)
+	)
+	if noise > 0 {
+		carrier += noise * (v.rand.Float64()*2 - 1)
+	}
+	for j := range v.modBands {
+		e := &v.envelopes[j]
+		e.setTimes(attack, release)
+		e.tick(math.Abs(v.modBands[j].tick(modulator)))
+	}
+
+	var out float64
+	for j := range v.carBands {
+		b := &v.carBands[j]
+		b.setCutoff(math.Min(v.freqs[j]*formant, vocoderMaxCutoff))
+		band := b.tick(carrier)
+		if k := j - shift; k >= 0 && k < len(v.envelopes) {
+			out += band * v.envelopes[k].value
+		}
+	}
+	v.out.Write(i, out)
+}
+
+// vocoderBand is a pair of band-pass filters in series, which gives the bands steeper sides so that they overlap
+// less.
+type vocoderBand struct {
+	filters [2]dsp.Biquad
+}
+
+func newVocoderBand(cutoff, q float64) vocoderBand {
+	var b vocoderBand
+	for i := range b.filters {
+		b.filters[i] = dsp.Biquad{Type: dsp.BandPass, Cutoff: cutoff, Q: q}
+	}
+	return b
+}
+
+func (b *vocoderBand) setCutoff(cutoff float64) {
+	b.filters[0].Cutoff = cutoff
+	b.filters[1].Cutoff = cutoff
+}
+
+func (b *vocoderBand) tick(in float64) float64 {
+	return b.filters[1].Tick(b.filters[0].Tick(in))
+}
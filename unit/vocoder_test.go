@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+// vocoderBandFreq returns the center frequency, in Hz, of a band of an 8 band vocoder.
+func vocoderBandFreq(band float64) float64 {
+	return vocoderLowest * math.Pow(vocoderHighest/vocoderLowest, band/7)
+}
+
+// vocoderLevel runs a vocoder with sines for its carrier and modulator, at frequencies in Hz, and returns the peak of
+// its output once it has settled.
+func vocoderLevel(t *testing.T, carrier, modulator float64, inputs map[string]float64) float64 {
+	u, err := Builders()["vocoder"](Config{
+		Values:     map[string]any{"bands": 8},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	var (
+		carFreq = dsp.Frequency(carrier, sampleRate).Float64()
+		modFreq = dsp.Frequency(modulator, sampleRate).Float64()
+		peak    float64
+	)
+	for f := 0; f < 60; f++ {
+		for i := 0; i < frameSize; i++ {
+			n := float64(f*frameSize + i)
+			u.In["carrier"].Write(i, math.Sin(2*math.Pi*carFreq*n))
+			u.In["modulator"].Write(i, math.Sin(2*math.Pi*modFreq*n))
+			for name, v := range inputs {
+				u.In[name].Write(i, v)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		if f >= 40 {
+			for i := 0; i < frameSize; i++ {
+				peak = math.Max(peak, math.Abs(u.Out["out"].Out().Read(i)))
+			}
+		}
+	}
+	return peak
+}
+
+func TestVocoder(t *testing.T) {
+	var (
+		matched    = vocoderLevel(t, vocoderBandFreq(2), vocoderBandFreq(2), nil)
+		mismatched = vocoderLevel(t, vocoderBandFreq(2), vocoderBandFreq(5), nil)
+		silent     = vocoderLevel(t, vocoderBandFreq(2), 0, nil)
+	)
+	require.True(t, matched > 0.5, "matched bands peak at %f", matched)
+	require.True(t, mismatched < 0.05*matched, "mismatched bands peak at %f", mismatched)
+	require.Equal(t, 0.0, silent)
+}
+
+func TestVocoder_Shift(t *testing.T) {
+	var (
+		low  = vocoderBandFreq(2)
+		high = vocoderBandFreq(4)
+	)
+	// Shifting by two bands moves the modulator's third band up to the carrier's fifth.
+	require.True(t, vocoderLevel(t, high, low, map[string]float64{"shift": 2}) > 0.5)
+	require.True(t, vocoderLevel(t, high, low, nil) < 0.05)
+
+	// Shifting the carrier's bands up by two bands' worth of semitones does the same with their frequencies.
+	semitones := 12 * math.Log2(high/low)
+	require.True(t, vocoderLevel(t, high, low, map[string]float64{"formant": semitones}) > 0.5)
+}
+
+func TestVocoder_Noise(t *testing.T) {
+	// Noise comes through the bands even when the carrier has nothing in them.
+	var (
+		quiet = vocoderLevel(t, vocoderBandFreq(0), vocoderBandFreq(5), nil)
+		noisy = vocoderLevel(t, vocoderBandFreq(0), vocoderBandFreq(5), map[string]float64{"noise": 1})
+	)
+	require.True(t, noisy > 10*quiet, "noisy output peaks at %f against %f", noisy, quiet)
+}
+
+func TestVocoder_InvalidConfig(t *testing.T) {
+	_, err := Builders()["vocoder"](Config{Values: map[string]any{"bands": 2}})
+	require.EqualError(t, err, "unit/vocoder: bands must be between 4 and 64, got 2")
+}
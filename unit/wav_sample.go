@@ -2,140 +2,176 @@ package unit
 
 import (
 	"math"
-	"os"
-
-	"github.com/go-audio/wav"
 
 	"github.com/brettbuddin/shaden/dsp"
-	"github.com/brettbuddin/shaden/errors"
 )
 
 func newWAVSample(io *IO, c Config) (*Unit, error) {
 	var config struct {
-		File string
+		File   string
+		Buffer string
+		Stream bool
+		Slice  bool
 	}
 	if err := c.Decode(&config); err != nil {
 		return nil, err
 	}
 
-	if config.File == "" {
-		return nil, errors.New("no WAV file specified")
+	if config.Stream {
+		if config.Buffer != "" {
+			return nil, c.errorf("buffers are held in memory and can't be streamed")
+		}
+		if config.Slice {
+			return nil, c.errorf("streamed files can't be sliced")
+		}
+		return newSampleStream(io, c, config.File)
 	}
 
-	f, err := os.Open(config.File)
+	buf, err := c.loadBuffer(config.File, config.Buffer)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	w := wav.NewDecoder(f)
-	if !w.IsValidFile() {
-		return nil, errors.Errorf("%q is not a valid WAV file", config.File)
+	w := &wavSample{
+		trigger:     io.NewIn("trigger", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("starts playback")),
+		direction:   io.NewIn("direction", dsp.Float64(1), WithDoc("forward when positive; reverse otherwise")),
+		begin:       io.NewIn("begin", dsp.Float64(0), WithRange(0, 1), WithDoc("start of the playback region")),
+		end:         io.NewIn("end", dsp.Float64(1), WithRange(0, 1), WithDoc("end of the playback region")),
+		cycle:       io.NewIn("cycle", dsp.Float64(0), WithMeasure(MeasureGate), WithDoc("loops the playback region while high")),
+		rate:        io.NewIn("rate", dsp.Float64(1), WithDoc("playback speed where 1 is the original speed")),
+		pitch:       io.NewIn("pitch", dsp.Float64(0), WithMeasure(MeasureSemitones), WithDoc("transposition of playback")),
+		crossfade:   io.NewIn("crossfade", dsp.Duration(0, c.SampleRate), WithDoc("length of the crossfade where the playback region loops")),
+		a:           io.NewOut("a", WithDoc("first channel")),
+		b:           io.NewOut("b", WithDoc("second channel")),
+		playhead:    playhead{data: buf.Data, channels: buf.Channels},
+		rateMult:    sampleRateRatio(buf.SampleRate, c.SampleRate),
+		frame:       make([]float64, 2),
+		lastTrigger: -1,
 	}
-
-	buf, err := w.FullPCMBuffer()
-	if err != nil {
-		return nil, err
+	if config.Slice {
+		w.slices = onsetSlices(buf.Data, buf.Channels, buf.SampleRate)
+		w.slice = io.NewIn("slice", dsp.Float64(0), WithMeasure(MeasureInteger), WithDoc("index of the slice played by the next trigger; wraps around the slice count"))
+		w.sliceCount = io.NewOut("slices", WithDoc("number of slices found in the file"))
 	}
-
-	var (
-		raw   = buf.AsFloat32Buffer().Data
-		frame = make([]float64, len(raw))
-	)
-	for i, s := range raw {
-		frame[i] = float64(s)
-	}
-
-	return NewUnit(io, &wavSample{
-		trigger:     io.NewIn("trigger", dsp.Float64(-1)),
-		direction:   io.NewIn("direction", dsp.Float64(1)),
-		begin:       io.NewIn("begin", dsp.Float64(0)),
-		end:         io.NewIn("end", dsp.Float64(1)),
-		cycle:       io.NewIn("cycle", dsp.Float64(0)),
-		a:           io.NewOut("a"),
-		b:           io.NewOut("b"),
-		channels:    buf.Format.NumChannels,
-		length:      buf.NumFrames(),
-		frame:       frame,
-		lastTrigger: -1,
-	}), nil
+	return NewUnit(io, w), nil
 }
 
 type wavSample struct {
 	trigger, begin, end, direction, cycle *In
-	length, channels                      int
-	frame                                 []float64
-	current                               int
+	rate, pitch, crossfade                *In
 	a, b                                  *Out
-	playing                               bool
+	playhead                              playhead
+	rateMult                              float64
+	frame                                 []float64
 	lastTrigger                           float64
+
+	// Slicing divides the file at its onsets. Begin and end select a region of the current slice.
+	slice      *In
+	sliceCount *Out
+	slices     []int // first frame of each slice
+	current    int
 }
 
 func (w *wavSample) ProcessSample(i int) {
 	var (
 		direction = w.direction.Read(i)
-		length    = float64(w.length - 1)
-		begin     = w.begin.Read(i)
-		end       = w.end.Read(i)
-		absBegin  = int(math.Min(end, dsp.Clamp(begin, 0, 0.95)) * length)
-		absEnd    = int(math.Max(begin, dsp.Clamp(end, 0.05, 1)) * length)
+		speed     = w.rate.Read(i) * math.Pow(2, w.pitch.Read(i)/12) * w.rateMult
 		trigger   = w.trigger.Read(i)
 		cycle     = w.cycle.Read(i)
-		channels  = w.channels
+		triggered = isTrig(w.lastTrigger, trigger)
 	)
-
-	// Trigger and reset
-	if isTrig(w.lastTrigger, trigger) {
-		if direction > 0 {
-			w.current = absBegin
-		} else {
-			w.current = absEnd
-		}
-		w.playing = true
+	if direction <= 0 {
+		speed = -speed
 	}
-
-	// Wrapping
-	if direction > 0 && w.current > absEnd {
-		w.current = absBegin
-		if cycle <= 0 {
-			w.playing = false
+	if w.slices != nil {
+		if triggered {
+			n := len(w.slices)
+			w.current = (int(w.slice.Read(i))%n + n) % n
 		}
+		w.sliceCount.Write(i, float64(len(w.slices)))
 	}
-	if direction <= 0 && w.current < absBegin {
-		w.current = absEnd
-		if cycle <= 0 {
-			w.playing = false
-		}
+
+	begin, end := w.region(w.begin.Read(i), w.end.Read(i))
+	if triggered {
+		w.playhead.start(begin, end, speed)
 	}
+	w.playhead.tick(w.frame, begin, end, speed, w.crossfade.Read(i), cycle > 0)
 
-	// Write output
-	if w.playing {
-		var left, right = w.a, w.b
-		if direction <= 0 {
-			right, left = left, right
-		}
+	w.a.Write(i, w.frame[0])
+	w.b.Write(i, w.frame[1])
+	w.lastTrigger = trigger
+}
 
-		left.Write(i, w.frame[w.current])
+// region converts a normalized playback region to frame positions. When slicing, the region is within the current
+// slice.
+func (w *wavSample) region(begin, end float64) (float64, float64) {
+	if w.slices == nil {
+		return w.playhead.region(begin, end)
+	}
+	first, next := w.slices[w.current], w.playhead.frames()
+	if w.current+1 < len(w.slices) {
+		next = w.slices[w.current+1]
+	}
+	b, e := playbackRegion(begin, end, next-first)
+	return float64(first) + b, float64(first) + e
+}
 
-		var incr int
-		switch channels {
-		case 1:
-			right.Write(i, 0)
-			incr = 1
-		case 2:
-			right.Write(i, w.frame[w.current+1])
-			incr = 2
+// onsetSlices returns the first frame of each slice of some audio divided at its onsets. The first slice always starts
+// at the beginning.
+func onsetSlices(data []float64, channels, sampleRate int) []int {
+	var (
+		detector = newOnsetDetector(sampleRate)
+		frames   = len(data) / channels
+		holdoff  = int(dsp.Duration(50, sampleRate).Float64())
+		slices   = []int{0}
+	)
+	// Run past the end by a frame of analysis so onsets near the end are found too.
+	for n := 0; n < frames+detector.Size(); n++ {
+		var mono float64
+		if n < frames {
+			for c := 0; c < channels; c++ {
+				mono += data[n*channels+c]
+			}
+			mono /= float64(channels)
 		}
+		if found, _ := detector.Tick(mono, 0.5); !found {
+			continue
+		}
+		start := onsetStart(data, channels, n-detector.Size(), min(n, frames))
+		if start-slices[len(slices)-1] >= holdoff {
+			slices = append(slices, start)
+		}
+	}
+	return slices
+}
 
-		if direction > 0 {
-			w.current += incr
-		} else if direction <= 0 {
-			w.current -= incr
+// onsetStart places an onset that was detected within frames [from, to) more precisely: at the start of the block of
+// frames whose energy rises the most over the block before it.
+func onsetStart(data []float64, channels, from, to int) int {
+	const block = 32
+	energy := func(first int) float64 {
+		var sum float64
+		for n := max(first, 0); n < min(first+block, to); n++ {
+			for c := 0; c < channels; c++ {
+				v := data[n*channels+c]
+				sum += v * v
+			}
 		}
-	} else {
-		w.a.Write(i, 0)
-		w.b.Write(i, 0)
+		return sum
 	}
 
-	w.lastTrigger = trigger
+	from = max(from, 0)
+	var (
+		start    = from
+		maxRise  = math.Inf(-1)
+		previous = energy(from - block)
+	)
+	for pos := from; pos < to; pos += block {
+		e := energy(pos)
+		if rise := e - previous; rise > maxRise {
+			start, maxRise = pos, rise
+		}
+		previous = e
+	}
+	return start
 }
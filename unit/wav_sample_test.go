@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+func TestWAVSample(t *testing.T) {
+	// Stereo: a rising ramp on the first channel and a falling one on the second.
+	samples := make([]float64, 200)
+	for i := 0; i < 100; i++ {
+		samples[2*i] = float64(i) / 100
+		samples[2*i+1] = -float64(i) / 100
+	}
+
+	u, err := Builders()["sample"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 2, samples)},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	u.In["trigger"].Write(0, -1)
+	for i := 1; i < frameSize; i++ {
+		u.In["trigger"].Write(i, 1)
+		u.In["rate"].Write(i, 0.5)
+	}
+	u.ProcessFrame(frameSize)
+
+	a, b := u.Out["a"].Out(), u.Out["b"].Out()
+	require.Equal(t, 0.0, a.Read(0))
+	require.InDelta(t, 0, a.Read(1), 1e-4)
+	require.InDelta(t, 0.1, a.Read(21), 1e-4)
+	require.InDelta(t, 0.105, a.Read(22), 1e-4)
+	require.InDelta(t, -0.1, b.Read(21), 1e-4)
+
+	// Finished playing the file.
+	require.Equal(t, 0.0, a.Read(250))
+}
+
+func TestWAVSample_Pitch(t *testing.T) {
+	samples := make([]float64, 100)
+	for i := range samples {
+		samples[i] = float64(i) / 100
+	}
+	u, err := Builders()["sample"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples)},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	u.In["trigger"].Write(0, -1)
+	u.In["trigger"].Write(1, 1)
+	u.In["trigger"].Write(2, 1)
+	u.In["pitch"].Write(1, 12)
+	u.In["pitch"].Write(2, 12)
+	u.ProcessSample(0)
+	u.ProcessSample(1)
+	u.ProcessSample(2)
+	require.InDelta(t, 0.02, u.Out["a"].Out().Read(2), 1e-4)
+	require.Equal(t, 0.0, u.Out["b"].Out().Read(2))
+}
+
+func TestWAVSample_SampleRateConversion(t *testing.T) {
+	// One second of a 480Hz sine recorded at 48kHz.
+	samples := make([]float64, 48000)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * 480 * float64(i) / 48000)
+	}
+	u, err := Builders()["sample"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, 48000, 1, samples)},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	var out []float64
+	u.In["trigger"].Write(0, -1)
+	for n := 0; n < 40; n++ {
+		for i := 0; i < frameSize; i++ {
+			if n > 0 || i > 0 {
+				u.In["trigger"].Write(i, 1)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			out = append(out, u.Out["a"].Out().Read(i))
+		}
+	}
+
+	// Count rising zero crossings over 441 samples (10ms at the engine's rate) to check the pitch is still 480Hz.
+	var crossings int
+	for i := 1000; i < 1000+4410; i++ {
+		if out[i-1] < 0 && out[i] >= 0 {
+			crossings++
+		}
+	}
+	require.Equal(t, 48, crossings)
+}
+
+func TestWAVSample_Slice(t *testing.T) {
+	hits := []int{5000, 15000, 25000}
+	samples := drumHits(30000, hits...)
+
+	slices := onsetSlices(samples, 1, sampleRate)
+	require.Len(t, slices, len(hits)+1)
+	require.Equal(t, 0, slices[0])
+	for i, h := range hits {
+		require.InDelta(t, h, slices[i+1], 32)
+	}
+
+	u, err := Builders()["sample"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples), "slice": true},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	// Play the third slice, selected by an index that wraps around the slice count.
+	u.In["trigger"].Write(0, -1)
+	for i := 1; i < frameSize; i++ {
+		u.In["trigger"].Write(i, 1)
+		u.In["slice"].Write(i, -2)
+	}
+	u.ProcessFrame(frameSize)
+	require.Equal(t, 4.0, u.Out["slices"].Out().Read(0))
+	for i := 1; i < frameSize; i++ {
+		require.InDelta(t, samples[slices[2]+i-1], u.Out["a"].Out().Read(i), 1e-4)
+	}
+
+	_, err = Builders()["sample"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples), "slice": true, "stream": true},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.Error(t, err)
+}
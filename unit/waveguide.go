@@ -0,0 +1,88 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+)
+
+const (
+	// waveguideMinFreq is the lowest frequency, in Hz, that waveguides are sized for.
+	waveguideMinFreq = 10
+	// waveguideMinPeriod is the shortest loop, in samples, that waveguides play.
+	waveguideMinPeriod = 8
+	// waveguideMaxDecay and waveguideMinDecay are the times, in seconds, that a waveguide takes to decay by 60dB with
+	// no damping and with full damping.
+	waveguideMaxDecay = 20
+	waveguideMinDecay = 0.1
+)
+
+// waveguideDelay is a delay line that's tuned to a fraction of a sample. The whole samples of the delay come from a
+// DelayLine and the fraction from a first-order allpass filter, which, unlike interpolating between samples, doesn't
+// dull the signal as it circulates.
+type waveguideDelay struct {
+	dl *dsp.DelayLine
+	ap dsp.FirstOrderAllPass
+}
+
+func newWaveguideDelay(size int) *waveguideDelay {
+	return &waveguideDelay{dl: dsp.NewDelayLine(size)}
+}
+
+// tick writes a sample to the delay and returns the sample from delay samples ago.
+func (w *waveguideDelay) tick(in, delay float64) float64 {
+	// The allpass is kept to a delay between 0.5 and 1.5 samples, where its phase is closest to linear.
+	var (
+		whole = math.Floor(delay - 0.5)
+		frac  = delay - whole
+	)
+	whole = dsp.Clamp(whole, 1, float64(w.dl.Size()-1))
+
+	// Reading before writing puts the sample written whole samples ago at position whole.
+	out := w.dl.ReadAbsolute(whole)
+	w.dl.Write(in)
+
+	return w.ap.Tick(out, (1-frac)/(1+frac))
+}
+
+// waveguideLoss is the loop filter of a waveguide: a two-tap lowpass followed by a gain. The lowpass delays low
+// frequencies by exactly its weight, in samples, which is subtracted from the loop's delay to keep it in tune.
+type waveguideLoss struct {
+	last float64
+}
+
+func (l *waveguideLoss) tick(in, weight, gain float64) float64 {
+	out := (1-weight)*in + weight*l.last
+	l.last = in
+	return out * gain
+}
+
+// waveguideWeight converts brightness to the weight of the loss filter. Full brightness passes everything and no
+// brightness averages neighbouring samples, as in the original Karplus-Strong algorithm.
+func waveguideWeight(brightness float64) float64 {
+	return 0.5 * (1 - dsp.Clamp(brightness, 0, 1))
+}
+
+// waveguideGain converts damping to the gain applied each time the signal travels around a loop of period samples.
+// The time taken to decay is independent of pitch and falls exponentially with damping.
+func waveguideGain(damping, period, sampleRate float64) float64 {
+	var (
+		d      = dsp.Clamp(damping, 0, 1)
+		decay  = waveguideMaxDecay * math.Pow(waveguideMinDecay/waveguideMaxDecay, d)
+		gainDB = -60 * period / (decay * sampleRate)
+	)
+	return math.Pow(10, gainDB/20)
+}
+
+// waveguidePeriod converts a frequency to a loop period in samples, limited to what a delay of size samples can hold.
+func waveguidePeriod(freq float64, size int) float64 {
+	if freq <= 0 {
+		return float64(size)
+	}
+	return dsp.Clamp(1/freq, waveguideMinPeriod, float64(size))
+}
+
+// waveguideSize returns the number of samples a waveguide needs to reach waveguideMinFreq.
+func waveguideSize(sampleRate int) int {
+	return sampleRate/waveguideMinFreq + 4
+}
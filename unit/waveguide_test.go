@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runWaveguide sets the unit's inputs to constant values, runs it for n samples and returns its output. A trigger input
+// is fired on the first sample.
+func runWaveguide(u *Unit, inputs map[string]float64, n int) []float64 {
+	var out []float64
+	for len(out) < n {
+		for name, v := range inputs {
+			for i := 0; i < frameSize; i++ {
+				u.In[name].Write(i, v)
+			}
+		}
+		if in, ok := u.In["trigger"]; ok {
+			for i := 0; i < frameSize; i++ {
+				in.Write(i, -1)
+			}
+			if len(out) == 0 {
+				in.Write(1, 1)
+			}
+		}
+		u.ProcessFrame(frameSize)
+		for i := 0; i < frameSize; i++ {
+			out = append(out, u.Out["out"].Out().Read(i))
+		}
+	}
+	return out[:n]
+}
+
+// measureFreq estimates the frequency of the fundamental of a signal near an expected frequency, both in cycles per
+// sample. The phase of the fundamental is measured at the start and end of the signal, and the difference between how
+// far it moved and how far it would've moved at the expected frequency gives the error.
+func measureFreq(samples []float64, expected float64) float64 {
+	const window = 4096
+	phase := func(offset int) float64 {
+		var re, im float64
+		for i := 0; i < window; i++ {
+			var (
+				w = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/window)
+				x = samples[offset+i] * w
+				t = 2 * math.Pi * expected * float64(offset+i)
+			)
+			re += x * math.Cos(t)
+			im -= x * math.Sin(t)
+		}
+		return math.Atan2(im, re)
+	}
+	var (
+		span  = len(samples) - window
+		drift = phase(span) - phase(0)
+	)
+	drift -= 2 * math.Pi * math.Round(drift/(2*math.Pi))
+	return expected + drift/(2*math.Pi*float64(span))
+}
+
+func cents(measured, expected float64) float64 {
+	return 1200 * math.Log2(measured/expected)
+}
+
+func TestPluck_Tuning(t *testing.T) {
+	for _, hz := range []float64{55, 110, 261.63, 440, 1046.5, 1975.5} {
+		for _, brightness := range []float64{0, 0.5, 1} {
+			u := newTestUnit(t, "pluck", nil)
+			out := runWaveguide(u, map[string]float64{
+				"pitch":      hz / sampleRate,
+				"brightness": brightness,
+				"damping":    0,
+			}, 16384)
+
+			require.InDelta(t, 0, cents(measureFreq(out[8192:], hz/sampleRate)*sampleRate, hz), 1, "%gHz at brightness %g", hz, brightness)
+		}
+	}
+}
+
+func TestPluck_Damping(t *testing.T) {
+	peak := func(samples []float64) float64 {
+		var p float64
+		for _, s := range samples {
+			p = math.Max(p, math.Abs(s))
+		}
+		return p
+	}
+
+	var decays []float64
+	for _, damping := range []float64{0, 0.5, 1} {
+		u := newTestUnit(t, "pluck", nil)
+		out := runWaveguide(u, map[string]float64{
+			"pitch":   220 / sampleRate,
+			"damping": damping,
+		}, 22050)
+		decays = append(decays, peak(out[20000:])/peak(out[:2000]))
+	}
+	require.True(t, decays[0] > decays[1] && decays[1] > decays[2], "%v", decays)
+	require.True(t, decays[2] < 0.01, "%v", decays)
+
+	// Silent until plucked.
+	u := newTestUnit(t, "pluck", nil)
+	u.ProcessFrame(frameSize)
+	require.Equal(t, 0.0, u.Out["out"].Out().Read(frameSize-1))
+}
+
+func TestBow(t *testing.T) {
+	u := newTestUnit(t, "bow", nil)
+	hz := 220.0
+	out := runWaveguide(u, map[string]float64{
+		"pitch": hz / sampleRate,
+		"gate":  1,
+	}, 16384)
+
+	var peak float64
+	for _, s := range out[8192:] {
+		peak = math.Max(peak, math.Abs(s))
+	}
+	require.True(t, peak > 0.05 && peak < 1, "peak %g", peak)
+	require.InDelta(t, 0, cents(measureFreq(out[8192:], hz/sampleRate)*sampleRate, hz), 10)
+
+	// The string dies away once the bow stops.
+	out = runWaveguide(u, map[string]float64{"gate": -1, "damping": 1}, 22050)
+	require.InDelta(t, 0, out[len(out)-1], 1e-3)
+}
@@ -0,0 +1,176 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/errors"
+)
+
+const defaultWavetableSize = 2048
+
+func newWavetable(io *IO, c Config) (*Unit, error) {
+	var config struct {
+		File   string
+		Buffer string
+		Size   int
+	}
+	if err := c.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Size == 0 {
+		config.Size = defaultWavetableSize
+	}
+	if config.Size < 4 || !dsp.IsPowerOfTwo(config.Size) {
+		return nil, c.errorf("size must be a power of two of at least 4; got %d", config.Size)
+	}
+
+	buf, err := c.loadBuffer(config.File, config.Buffer)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := newWavetableFrames(buf.Mono(), config.Size)
+	if err != nil {
+		return nil, c.errorf("%q: %s", firstNonEmpty(config.Buffer, config.File), err)
+	}
+
+	return NewUnit(io, &wavetable{
+		freq:     io.NewIn("freq", dsp.Frequency(440, c.SampleRate), WithDoc("frequency of the oscillator")),
+		fm:       io.NewIn("freq-mod", dsp.Float64(0), WithMeasure(MeasureHz), WithDoc("linear frequency modulation")),
+		position: io.NewIn("position", dsp.Float64(0), WithRange(0, 1), WithDoc("morphs between the frames of the table")),
+		amp:      io.NewIn("amp", dsp.Float64(1), WithDoc("amplitude")),
+		offset:   io.NewIn("offset", dsp.Float64(0), WithDoc("amount added to the output")),
+		sync:     io.NewIn("sync", dsp.Float64(-1), WithMeasure(MeasureTrigger), WithDoc("resets the phase")),
+		out:      io.NewOut("out", WithDoc("wavetable output")),
+		frames:   tables,
+		size:     config.Size,
+		lastSync: -1,
+	}), nil
+}
+
+// wavetableFrame is a single cycle of a wavetable, stored as a series of mip-maps. Each successive level contains half
+// as many harmonics as the one before it, so there's always a level that can be played back without aliasing.
+type wavetableFrame [][]float64
+
+// newWavetableFrames splits samples into frames of a specific size and builds the mip-maps for each of them. A file
+// that's shorter than a single frame is treated as one cycle and stretched to the size.
+func newWavetableFrames(samples []float64, size int) ([]wavetableFrame, error) {
+	if len(samples) < size {
+		samples = stretchCycle(samples, size)
+	}
+	if len(samples)%size != 0 {
+		return nil, errors.Errorf("length of %d samples is not a multiple of the table size %d", len(samples), size)
+	}
+
+	var (
+		frames = make([]wavetableFrame, len(samples)/size)
+		levels = int(math.Log2(float64(size)))
+		spec   = make([]complex128, size)
+		work   = make([]complex128, size)
+	)
+	for f := range frames {
+		cycle := samples[f*size : (f+1)*size]
+		for i, s := range cycle {
+			spec[i] = complex(s, 0)
+		}
+		dsp.FFT(spec)
+
+		frame := make(wavetableFrame, levels)
+		for l := range frame {
+			// Level l keeps harmonics up to size/2^(l+1).
+			harmonics := size >> uint(l+1)
+			for k := range work {
+				h := k
+				if h > size/2 {
+					h = size - k
+				}
+				if h <= harmonics {
+					work[k] = spec[k]
+				} else {
+					work[k] = 0
+				}
+			}
+			dsp.IFFT(work)
+
+			table := make([]float64, size+1)
+			for i := 0; i < size; i++ {
+				table[i] = real(work[i])
+			}
+			table[size] = table[0]
+			frame[l] = table
+		}
+		frames[f] = frame
+	}
+	return frames, nil
+}
+
+// stretchCycle resamples a single cycle to a new length using linear interpolation.
+func stretchCycle(cycle []float64, size int) []float64 {
+	var (
+		out   = make([]float64, size)
+		ratio = float64(len(cycle)) / float64(size)
+	)
+	for i := range out {
+		pos := float64(i) * ratio
+		j := int(pos)
+		out[i] = dsp.Lerp(cycle[j], cycle[(j+1)%len(cycle)], pos-float64(j))
+	}
+	return out
+}
+
+type wavetable struct {
+	freq, fm, position, amp, offset, sync *In
+	out                                   *Out
+	frames                                []wavetableFrame
+	size                                  int
+	phase, lastSync                       float64
+}
+
+func (w *wavetable) ProcessSample(i int) {
+	var (
+		freq     = w.freq.Read(i) + w.fm.Read(i)
+		position = dsp.Clamp(w.position.Read(i), 0, 1)
+		amp      = w.amp.Read(i)
+		offset   = w.offset.Read(i)
+		sync     = w.sync.Read(i)
+	)
+
+	if isTrig(w.lastSync, sync) {
+		w.phase = 0
+	}
+
+	var (
+		level = w.level(math.Abs(freq))
+		pos   = position * float64(len(w.frames)-1)
+		f     = int(pos)
+		next  = w.read(w.frames[f][level])
+	)
+	if f+1 < len(w.frames) {
+		next = dsp.Lerp(next, w.read(w.frames[f+1][level]), pos-float64(f))
+	}
+	w.out.Write(i, amp*next+offset)
+
+	w.phase += freq
+	w.phase -= math.Floor(w.phase)
+	w.lastSync = sync
+}
+
+// level returns the mip-map level with the most harmonics that stays below Nyquist at a normalized frequency.
+func (w *wavetable) level(freq float64) int {
+	cycles := freq * float64(w.size)
+	if cycles < 1 {
+		return 0
+	}
+	level := int(math.Log2(cycles)) + 1
+	if max := len(w.frames[0]) - 1; level > max {
+		return max
+	}
+	return level
+}
+
+func (w *wavetable) read(table []float64) float64 {
+	pos := w.phase * float64(w.size)
+	i := int(pos)
+	return dsp.Lerp(table[i], table[i+1], pos-float64(i))
+}
@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brettbuddin/shaden/dsp"
+	"github.com/brettbuddin/shaden/randtest"
+)
+
+func TestWavetable(t *testing.T) {
+	const size = 256
+
+	// Two frames: a sawtooth followed by a sine.
+	samples := make([]float64, 2*size)
+	for i := 0; i < size; i++ {
+		p := float64(i) / size
+		samples[i] = 2*p - 1
+		samples[size+i] = math.Sin(2 * math.Pi * p)
+	}
+
+	u, err := Builders()["wavetable"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples), "size": size},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	w := u.SampleProcessor.(*wavetable)
+	require.Len(t, w.frames, 2)
+	require.Len(t, w.frames[0], 8)
+
+	// The first level is the table as it was written.
+	require.InDelta(t, math.Sin(2*math.Pi*0.25), w.frames[1][0][size/4], 1e-3)
+
+	// The last level contains only the fundamental (and the small offset of the sampled sawtooth).
+	for i := 0; i < size; i++ {
+		require.InDelta(t, -2/math.Pi*math.Sin(2*math.Pi*float64(i)/size), w.frames[0][7][i], 2e-2)
+	}
+
+	freq := dsp.Frequency(1000, sampleRate).Float64()
+	require.Equal(t, 3, w.level(freq))
+	require.Equal(t, 0, w.level(dsp.Frequency(100, sampleRate).Float64()))
+	require.Equal(t, 7, w.level(0.5))
+
+	// Halfway through the second frame, a quarter of the way through the cycle.
+	u.In["freq"].Write(0, 0.25)
+	u.In["position"].Write(0, 1)
+	u.In["freq"].Write(1, 0.25)
+	u.In["position"].Write(1, 1)
+	w.phase = 0
+	w.ProcessSample(0)
+	w.ProcessSample(1)
+	require.InDelta(t, 0, u.Out["out"].Out().Read(0), 1e-3)
+	require.InDelta(t, 1, u.Out["out"].Out().Read(1), 1e-2)
+
+	// Morphing halfway between the frames.
+	u.In["freq"].Write(2, 0)
+	u.In["position"].Write(2, 0.5)
+	w.phase = 0.75
+	w.ProcessSample(2)
+	require.InDelta(t, (w.read(w.frames[0][0])+w.read(w.frames[1][0]))/2, u.Out["out"].Out().Read(2), 1e-9)
+}
+
+func TestWavetable_SingleCycle(t *testing.T) {
+	samples := make([]float64, 600)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * float64(i) / 600)
+	}
+
+	u, err := Builders()["wavetable"](Config{
+		Values:     map[string]any{"file": writeTestWAV(t, sampleRate, 1, samples)},
+		Rand:       randtest.Static(),
+		SampleRate: sampleRate,
+		FrameSize:  frameSize,
+	})
+	require.NoError(t, err)
+
+	w := u.SampleProcessor.(*wavetable)
+	require.Len(t, w.frames, 1)
+	require.Len(t, w.frames[0][0], defaultWavetableSize+1)
+	require.InDelta(t, 1, w.frames[0][0][defaultWavetableSize/4], 1e-3)
+}
+
+func TestWavetable_InvalidConfig(t *testing.T) {
+	builder := Builders()["wavetable"]
+	config := func(values map[string]any) Config {
+		return Config{Values: values, Rand: randtest.Static(), SampleRate: sampleRate, FrameSize: frameSize}
+	}
+
+	_, err := builder(config(nil))
+	require.EqualError(t, err, "unit/wavetable: no file or buffer specified")
+
+	path := writeTestWAV(t, sampleRate, 1, make([]float64, 300))
+	_, err = builder(config(map[string]any{"file": path, "size": 100}))
+	require.EqualError(t, err, "unit/wavetable: size must be a power of two of at least 4; got 100")
+
+	_, err = builder(config(map[string]any{"file": path, "size": 128}))
+	require.EqualError(t, err, `unit/wavetable: "`+path+`": length of 300 samples is not a multiple of the table size 128`)
+}